@@ -0,0 +1,92 @@
+package donately
+
+import (
+	"context"
+	"sync"
+)
+
+const defaultBatchConcurrency = 4
+
+// WithBatchConcurrency returns a ClientOption that sets how many lookups the batch helpers
+// (FindDonations, FindPeople and FindSubscriptions) run at once. Every lookup still goes through
+// the client, so rate limiting, retries and the circuit breaker apply to each of them.
+// If not provided, defaults to 4.
+func WithBatchConcurrency(n int) ClientOption {
+	return func(opt *clientOption) {
+		opt.batchConcurrency = n
+	}
+}
+
+// Result is the outcome of looking up a single ID as part of a batch.
+type Result[T any] struct {
+	// ID is the ID that was looked up.
+	ID string
+
+	// Value is the record found, or the zero value when Err is set.
+	Value T
+
+	// Err is the error returned by the lookup, if any.
+	Err error
+}
+
+// findAll looks up every ID with find, running at most concurrency lookups at once.
+// Results are returned in the order of ids. Once ctx is done, lookups that haven't
+// started yet fail with its error.
+func findAll[T any](ctx context.Context, concurrency int, ids []string, find func(context.Context, string) (T, error)) []Result[T] {
+	results := make([]Result[T], len(ids))
+	if len(ids) == 0 {
+		return results
+	}
+
+	if concurrency <= 0 {
+		concurrency = defaultBatchConcurrency
+	}
+
+	indexes := make(chan int)
+
+	var wg sync.WaitGroup
+	for range min(concurrency, len(ids)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for i := range indexes {
+				results[i].ID = ids[i]
+
+				if err := ctx.Err(); err != nil {
+					results[i].Err = err
+					continue
+				}
+
+				results[i].Value, results[i].Err = find(ctx, ids[i])
+			}
+		}()
+	}
+
+	for i := range ids {
+		indexes <- i
+	}
+	close(indexes)
+
+	wg.Wait()
+
+	return results
+}
+
+func (c *donatelyClient) FindDonations(ctx context.Context, ids []string, account Account) []Result[Donation] {
+	return findAll(ctx, c.opts.batchConcurrency, ids, func(ctx context.Context, id string) (Donation, error) {
+		return c.FindDonation(ctx, id, account)
+	})
+}
+
+func (c *donatelyClient) FindPeople(ctx context.Context, ids []string, account Account) []Result[Person] {
+	return findAll(ctx, c.opts.batchConcurrency, ids, func(ctx context.Context, id string) (Person, error) {
+		return c.FindPerson(ctx, id, account)
+	})
+}
+
+func (c *donatelyClient) FindSubscriptions(ctx context.Context, ids []string, account Account) []Result[Subscription] {
+	return findAll(ctx, c.opts.batchConcurrency, ids, func(ctx context.Context, id string) (Subscription, error) {
+		return c.FindSubscription(ctx, id, account)
+	})
+}
@@ -0,0 +1,134 @@
+package donately
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindDonations(t *testing.T) {
+	var inFlight, maxInFlight atomic.Int32
+
+	server, client := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+
+		for {
+			current := maxInFlight.Load()
+			if n <= current || maxInFlight.CompareAndSwap(current, n) {
+				break
+			}
+		}
+
+		assert.Equal(t, "acc_123", r.URL.Query().Get("account_id"))
+
+		id := strings.TrimPrefix(r.URL.Path, "/donations/")
+		if id == "don_missing" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		// Finish out of order so results have to be put back in place.
+		var i int
+		fmt.Sscanf(id, "don_%d", &i)
+		time.Sleep(time.Duration(10-i%10) * time.Millisecond)
+
+		json.NewEncoder(w).Encode(APIResponse{Data: mustMarshal(t, Donation{ID: id})})
+	}, WithBatchConcurrency(3))
+	defer server.Close()
+
+	var ids []string
+	for i := range 12 {
+		ids = append(ids, fmt.Sprintf("don_%d", i))
+	}
+	ids = append(ids[:5], append([]string{"don_missing"}, ids[5:]...)...)
+
+	results := client.FindDonations(context.Background(), ids, Account{ID: "acc_123"})
+	require.Len(t, results, len(ids))
+
+	for i, result := range results {
+		assert.Equal(t, ids[i], result.ID)
+
+		if result.ID == "don_missing" {
+			assert.ErrorIs(t, result.Err, ErrNotFound)
+			assert.Empty(t, result.Value.ID)
+			continue
+		}
+
+		require.NoError(t, result.Err)
+		assert.Equal(t, ids[i], result.Value.ID)
+	}
+
+	assert.LessOrEqual(t, maxInFlight.Load(), int32(3))
+}
+
+func TestFindPeopleAndSubscriptions(t *testing.T) {
+	server, client := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/people/"):
+			json.NewEncoder(w).Encode(APIResponse{Data: mustMarshal(t, Person{ID: strings.TrimPrefix(r.URL.Path, "/people/")})})
+		case strings.HasPrefix(r.URL.Path, "/subscriptions/"):
+			json.NewEncoder(w).Encode(APIResponse{Data: mustMarshal(t, Subscription{ID: strings.TrimPrefix(r.URL.Path, "/subscriptions/")})})
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+	})
+	defer server.Close()
+
+	people := client.FindPeople(context.Background(), []string{"person_1", "person_2"}, Account{ID: "acc_123"})
+	require.Len(t, people, 2)
+	assert.Equal(t, "person_1", people[0].Value.ID)
+	assert.Equal(t, "person_2", people[1].Value.ID)
+
+	subscriptions := client.FindSubscriptions(context.Background(), []string{"sub_1"}, Account{ID: "acc_123"})
+	require.Len(t, subscriptions, 1)
+	assert.NoError(t, subscriptions[0].Err)
+	assert.Equal(t, "sub_1", subscriptions[0].Value.ID)
+
+	assert.Empty(t, client.FindSubscriptions(context.Background(), nil, Account{ID: "acc_123"}))
+}
+
+func TestFindDonationsRespectsRateLimit(t *testing.T) {
+	var requests atomic.Int32
+	server, client := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		json.NewEncoder(w).Encode(APIResponse{Data: mustMarshal(t, Donation{ID: "don"})})
+	}, WithRateLimit(50, 1), WithBatchConcurrency(8))
+	defer server.Close()
+
+	start := time.Now()
+	results := client.FindDonations(context.Background(), []string{"a", "b", "c", "d", "e", "f"}, Account{ID: "acc_123"})
+
+	for _, result := range results {
+		assert.NoError(t, result.Err)
+	}
+
+	assert.Equal(t, int32(6), requests.Load())
+	assert.GreaterOrEqual(t, time.Since(start), 90*time.Millisecond)
+}
+
+func TestFindDonationsCanceled(t *testing.T) {
+	server, client := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Error("no request should be made once the context is canceled")
+	})
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	results := client.FindDonations(ctx, []string{"don_1", "don_2"}, Account{ID: "acc_123"})
+	require.Len(t, results, 2)
+
+	for i, result := range results {
+		assert.Equal(t, []string{"don_1", "don_2"}[i], result.ID)
+		assert.ErrorIs(t, result.Err, context.Canceled)
+	}
+}
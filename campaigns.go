@@ -1,6 +1,9 @@
 package donately
 
-import "time"
+import (
+	"net/url"
+	"time"
+)
 
 // Campaign represents a fundraising campaign with its goals,
 // progress, content, and associated metadata.
@@ -60,3 +63,65 @@ type CampaignCoverPhotoSizes struct {
 	Original *string `json:"original"`
 	Large    *string `json:"large"`
 }
+
+// Sort fields accepted by CampaignQuery.SortBy.
+const (
+	CampaignSortAmountRaised  = "amount_raised_in_cents"
+	CampaignSortPercentFunded = "percent_funded"
+)
+
+// CampaignQuery filters, sorts and pages the campaigns returned by SearchCampaigns.
+// Zero-valued fields are ignored.
+type CampaignQuery struct {
+	// Status restricts results to campaigns with the given status (e.g. "active").
+	Status string
+
+	// Type restricts results to campaigns of the given type.
+	Type string
+
+	// StartDate restricts results to campaigns starting within the range.
+	StartDate TimeRange
+
+	// EndDate restricts results to campaigns ending within the range.
+	EndDate TimeRange
+
+	// Updated restricts results to campaigns last updated within the range.
+	Updated TimeRange
+
+	// SortBy is the campaign field to sort by, such as CampaignSortAmountRaised or CampaignSortPercentFunded.
+	SortBy string
+
+	// Order is the direction results are sorted in. If not provided, the API's default order is used.
+	Order SortOrder
+
+	// Offset is the number of matching campaigns to skip.
+	Offset int
+
+	// Limit is the maximum number of campaigns to return.
+	Limit int
+}
+
+func (q CampaignQuery) validate() error {
+	if err := q.StartDate.validate("start date"); err != nil {
+		return err
+	}
+
+	if err := q.EndDate.validate("end date"); err != nil {
+		return err
+	}
+
+	return q.Updated.validate("updated")
+}
+
+func (q CampaignQuery) values() url.Values {
+	params := url.Values{}
+
+	setString(params, "status", q.Status)
+	setString(params, "type", q.Type)
+	q.StartDate.setDates(params, "start_date")
+	q.EndDate.setDates(params, "end_date")
+	q.Updated.set(params, "updated")
+	setSort(params, q.SortBy, q.Order)
+
+	return params
+}
@@ -0,0 +1,303 @@
+package donately
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+)
+
+// ErrNoInteraction is returned during replay when no unused recorded interaction matches a request.
+var ErrNoInteraction = errors.New("donately: no recorded interaction matches request")
+
+// CassetteMode selects whether a Cassette records real interactions or replays recorded ones.
+type CassetteMode int
+
+const (
+	// CassetteReplay serves responses from a previously recorded cassette without touching the network.
+	CassetteReplay CassetteMode = iota
+
+	// CassetteRecord forwards requests to the real transport and writes every interaction to the cassette.
+	CassetteRecord
+)
+
+// Interaction is a single recorded request/response pair, stored as one JSON line in a cassette.
+// Bearer tokens and personal information are scrubbed before an interaction is written.
+type Interaction struct {
+	Method         string      `json:"method"`
+	Path           string      `json:"path"`
+	Query          string      `json:"query,omitempty"`
+	RequestHeader  http.Header `json:"request_header,omitempty"`
+	RequestBody    string      `json:"request_body,omitempty"`
+	StatusCode     int         `json:"status_code"`
+	ResponseHeader http.Header `json:"response_header,omitempty"`
+	ResponseBody   string      `json:"response_body,omitempty"`
+}
+
+// Matcher reports whether an incoming request, scrubbed the same way as recorded interactions,
+// matches a recorded interaction.
+type Matcher func(recorded, incoming Interaction) bool
+
+// MatchMethod matches interactions with the same HTTP method.
+func MatchMethod(recorded, incoming Interaction) bool {
+	return recorded.Method == incoming.Method
+}
+
+// MatchPath matches interactions with the same URL path.
+func MatchPath(recorded, incoming Interaction) bool {
+	return recorded.Path == incoming.Path
+}
+
+// MatchQuery matches interactions with the same query parameters, regardless of their order.
+func MatchQuery(recorded, incoming Interaction) bool {
+	return sameValues(recorded.Query, incoming.Query)
+}
+
+// MatchFormBody matches interactions with the same form encoded body, regardless of field order.
+// Bodies that aren't form encoded must be identical.
+func MatchFormBody(recorded, incoming Interaction) bool {
+	if strings.HasPrefix(incoming.RequestHeader.Get("Content-Type"), "application/x-www-form-urlencoded") {
+		return sameValues(recorded.RequestBody, incoming.RequestBody)
+	}
+
+	return recorded.RequestBody == incoming.RequestBody
+}
+
+func sameValues(a, b string) bool {
+	va, errA := url.ParseQuery(a)
+	vb, errB := url.ParseQuery(b)
+	if errA != nil || errB != nil {
+		return a == b
+	}
+
+	return va.Encode() == vb.Encode()
+}
+
+// CassetteOption configures a Cassette.
+type CassetteOption func(*Cassette)
+
+// WithCassetteTransport returns a CassetteOption that sets the transport used to reach the API
+// while recording. If not provided, http.DefaultTransport is used.
+func WithCassetteTransport(transport http.RoundTripper) CassetteOption {
+	return func(c *Cassette) {
+		c.transport = transport
+	}
+}
+
+// WithCassetteMatchers returns a CassetteOption that sets the matchers used to find the recorded
+// interaction for a request during replay. If not provided, requests must match on method, path,
+// query and form body.
+func WithCassetteMatchers(matchers ...Matcher) CassetteOption {
+	return func(c *Cassette) {
+		c.matchers = matchers
+	}
+}
+
+// Cassette is an http.RoundTripper that records interactions with the Donately API to a JSON Lines
+// file and replays them deterministically, so tests can run against fixtures captured from real traffic.
+// Use it with WithTransport, and Close it once done recording.
+type Cassette struct {
+	mode      CassetteMode
+	transport http.RoundTripper
+	matchers  []Matcher
+
+	mu           sync.Mutex
+	file         *os.File
+	interactions []Interaction
+	used         []bool
+}
+
+// NewCassette opens the cassette at path. In CassetteRecord mode the file is created (or truncated);
+// in CassetteReplay mode its interactions are loaded.
+func NewCassette(path string, mode CassetteMode, options ...CassetteOption) (*Cassette, error) {
+	c := &Cassette{
+		mode:      mode,
+		transport: http.DefaultTransport,
+		matchers:  []Matcher{MatchMethod, MatchPath, MatchQuery, MatchFormBody},
+	}
+
+	for _, option := range options {
+		option(c)
+	}
+
+	switch mode {
+	case CassetteRecord:
+		file, err := os.Create(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create cassette: %w", err)
+		}
+
+		c.file = file
+	case CassetteReplay:
+		interactions, err := readInteractions(path)
+		if err != nil {
+			return nil, err
+		}
+
+		c.interactions = interactions
+		c.used = make([]bool, len(interactions))
+	default:
+		return nil, fmt.Errorf("unknown cassette mode %d", mode)
+	}
+
+	return c, nil
+}
+
+func readInteractions(path string) ([]Interaction, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open cassette: %w", err)
+	}
+	defer file.Close()
+
+	var interactions []Interaction
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		var interaction Interaction
+		if err := json.Unmarshal(line, &interaction); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal cassette interaction %d: %w", len(interactions)+1, err)
+		}
+
+		interactions = append(interactions, interaction)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read cassette: %w", err)
+	}
+
+	return interactions, nil
+}
+
+// RoundTrip records or replays a single request.
+func (c *Cassette) RoundTrip(req *http.Request) (*http.Response, error) {
+	var reqBody []byte
+	if req.Body != nil {
+		var err error
+		reqBody, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read request body: %w", err)
+		}
+
+		req.Body = io.NopCloser(bytes.NewReader(reqBody))
+	}
+
+	incoming := scrubRequest(req, reqBody)
+
+	if c.mode == CassetteRecord {
+		return c.record(req, incoming)
+	}
+
+	return c.replay(req, incoming)
+}
+
+func (c *Cassette) record(req *http.Request, interaction Interaction) (*http.Response, error) {
+	resp, err := c.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	interaction.StatusCode = resp.StatusCode
+	interaction.ResponseHeader = resp.Header.Clone()
+	interaction.ResponseHeader.Del("Set-Cookie")
+	interaction.ResponseHeader.Del("Content-Length")
+	interaction.ResponseBody = string(redactBody(respBody, resp.Header.Get("Content-Type")))
+
+	line, err := json.Marshal(interaction)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal interaction: %w", err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, err := c.file.Write(append(line, '\n')); err != nil {
+		return nil, fmt.Errorf("failed to write cassette: %w", err)
+	}
+
+	return resp, nil
+}
+
+func (c *Cassette) replay(req *http.Request, incoming Interaction) (*http.Response, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for i, recorded := range c.interactions {
+		if c.used[i] || !c.matches(recorded, incoming) {
+			continue
+		}
+
+		c.used[i] = true
+
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", recorded.StatusCode, http.StatusText(recorded.StatusCode)),
+			StatusCode:    recorded.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        recorded.ResponseHeader.Clone(),
+			Body:          io.NopCloser(strings.NewReader(recorded.ResponseBody)),
+			ContentLength: int64(len(recorded.ResponseBody)),
+			Request:       req,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("%w: %s %s", ErrNoInteraction, incoming.Method, incoming.Path)
+}
+
+func (c *Cassette) matches(recorded, incoming Interaction) bool {
+	for _, match := range c.matchers {
+		if !match(recorded, incoming) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Close flushes and closes the cassette file when recording. It is a no-op when replaying.
+func (c *Cassette) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.file == nil {
+		return nil
+	}
+
+	err := c.file.Close()
+	c.file = nil
+	return err
+}
+
+// scrubRequest converts req into an Interaction with credentials and personal information removed.
+func scrubRequest(req *http.Request, body []byte) Interaction {
+	return Interaction{
+		Method:        req.Method,
+		Path:          req.URL.Path,
+		Query:         redactValues(req.URL.RawQuery),
+		RequestHeader: redactHeader(req.Header),
+		RequestBody:   string(redactBody(body, req.Header.Get("Content-Type"))),
+	}
+}
@@ -0,0 +1,143 @@
+package donately
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCassetteRecordAndReplay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "people.jsonl")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/people":
+			require.NoError(t, r.ParseForm())
+			json.NewEncoder(w).Encode(APIResponse{Data: mustMarshal(t, Person{
+				ID:        "person_new",
+				FirstName: r.Form.Get("first_name"),
+				City:      r.Form.Get("city"),
+				Email:     r.Form.Get("email"),
+			})})
+		default:
+			json.NewEncoder(w).Encode(APIResponse{Data: mustMarshal(t, Account{ID: "acc_123", Title: "Recorded"})})
+		}
+	}))
+	defer server.Close()
+
+	recorder, err := NewCassette(path, CassetteRecord)
+	require.NoError(t, err)
+
+	client, err := NewDonatelyClient(WithAPIKey("secret-api-key"), WithBaseURL(server.URL), WithTransport(recorder))
+	require.NoError(t, err)
+
+	person := Person{FirstName: "John", LastName: "Smith", City: "Springfield", Email: "john@example.com", Accounts: []Account{{ID: "acc_123"}}}
+
+	_, err = client.FindAccount(context.Background(), "acc_123")
+	require.NoError(t, err)
+	recordedPerson, err := client.SavePerson(context.Background(), person)
+	require.NoError(t, err)
+	assert.Equal(t, "john@example.com", recordedPerson.Email)
+	require.NoError(t, recorder.Close())
+
+	contents, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(contents), "secret-api-key")
+	assert.NotContains(t, string(contents), "john@example.com")
+	assert.NotContains(t, string(contents), "john%40example.com")
+	assert.NotContains(t, string(contents), "John")
+	assert.NotContains(t, string(contents), "Smith")
+	assert.NotContains(t, string(contents), "Springfield")
+
+	server.Close()
+
+	player, err := NewCassette(path, CassetteReplay)
+	require.NoError(t, err)
+
+	client, err = NewDonatelyClient(WithAPIKey("another-api-key"), WithBaseURL(server.URL), WithTransport(player))
+	require.NoError(t, err)
+
+	account, err := client.FindAccount(context.Background(), "acc_123")
+	require.NoError(t, err)
+	assert.Equal(t, "Recorded", account.Title)
+
+	replayedPerson, err := client.SavePerson(context.Background(), person)
+	require.NoError(t, err)
+	assert.Equal(t, "person_new", replayedPerson.ID)
+	assert.Equal(t, redacted, replayedPerson.FirstName)
+	assert.Equal(t, redacted, replayedPerson.City)
+	assert.Equal(t, redacted, replayedPerson.Email)
+
+	_, err = client.FindAccount(context.Background(), "acc_123")
+	assert.ErrorIs(t, err, ErrNoInteraction)
+}
+
+func TestCassetteMatchers(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "donations.jsonl")
+	lines := []Interaction{
+		{Method: "GET", Path: "/donations", Query: "account_id=acc_123&limit=10", StatusCode: 200, ResponseBody: `{"data":[{"id":"don_1"}]}`},
+		{Method: "GET", Path: "/donations", Query: "account_id=acc_123&limit=10&offset=10", StatusCode: 200, ResponseBody: `{"data":[{"id":"don_2"}]}`},
+	}
+
+	file, err := os.Create(path)
+	require.NoError(t, err)
+	for _, line := range lines {
+		require.NoError(t, json.NewEncoder(file).Encode(line))
+	}
+	require.NoError(t, file.Close())
+
+	player, err := NewCassette(path, CassetteReplay)
+	require.NoError(t, err)
+
+	client, err := NewDonatelyClient(WithAPIKey("test-api-key"), WithBaseURL("https://donately.invalid"), WithTransport(player))
+	require.NoError(t, err)
+
+	donations, err := client.ListDonations(context.Background(), Account{ID: "acc_123"}, 10, 10)
+	require.NoError(t, err)
+	require.Len(t, donations, 1)
+	assert.Equal(t, "don_2", donations[0].ID)
+
+	donations, err = client.ListDonations(context.Background(), Account{ID: "acc_123"}, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, donations, 1)
+	assert.Equal(t, "don_1", donations[0].ID)
+
+	t.Run("custom matchers", func(t *testing.T) {
+		player, err := NewCassette(path, CassetteReplay, WithCassetteMatchers(MatchMethod, MatchPath))
+		require.NoError(t, err)
+
+		client, err := NewDonatelyClient(WithAPIKey("test-api-key"), WithBaseURL("https://donately.invalid"), WithTransport(player))
+		require.NoError(t, err)
+
+		for _, expected := range []string{"don_1", "don_2"} {
+			donations, err := client.ListDonations(context.Background(), Account{ID: "acc_999"}, 0, 0)
+			require.NoError(t, err)
+			require.Len(t, donations, 1)
+			assert.Equal(t, expected, donations[0].ID)
+		}
+	})
+}
+
+func TestMatchFormBody(t *testing.T) {
+	form := http.Header{"Content-Type": {"application/x-www-form-urlencoded"}}
+
+	assert.True(t, MatchFormBody(
+		Interaction{RequestBody: "a=1&b=2"},
+		Interaction{RequestBody: "b=2&a=1", RequestHeader: form},
+	))
+	assert.False(t, MatchFormBody(
+		Interaction{RequestBody: "a=1&b=2"},
+		Interaction{RequestBody: "a=1&b=3", RequestHeader: form},
+	))
+	assert.False(t, MatchFormBody(
+		Interaction{RequestBody: `{"a":1}`},
+		Interaction{RequestBody: `{"a":2}`},
+	))
+}
@@ -0,0 +1,202 @@
+package donately
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+)
+
+// CheckpointKey identifies a saved position.
+type CheckpointKey struct {
+	// AccountID is the account being walked.
+	AccountID string `json:"account_id"`
+
+	// Resource names the list being walked: "people", "donations", "subscriptions" or "campaigns"
+	// for the All* iterators, and "sync:" followed by the same names for the Sync* methods.
+	Resource string `json:"resource"`
+}
+
+func (k CheckpointKey) String() string {
+	return k.AccountID + "/" + k.Resource
+}
+
+// Checkpoint is the saved position of a paged iteration or sync.
+type Checkpoint struct {
+	// Offset is the offset of the next page an iterator will fetch.
+	Offset int `json:"offset,omitempty"`
+
+	// Cursor is the cursor a sync resumes from.
+	Cursor *SyncCursor `json:"cursor,omitempty"`
+}
+
+// CheckpointStore persists the position of long-running iterations so that an interrupted run
+// resumes where it stopped instead of starting over. Implementations must be safe for concurrent use.
+type CheckpointStore interface {
+	// Load returns the checkpoint saved under key. The boolean is false when there is none.
+	Load(ctx context.Context, key CheckpointKey) (Checkpoint, bool, error)
+
+	// Save stores checkpoint under key, replacing any previous one.
+	Save(ctx context.Context, key CheckpointKey, checkpoint Checkpoint) error
+
+	// Delete removes the checkpoint saved under key, if any.
+	Delete(ctx context.Context, key CheckpointKey) error
+}
+
+// MemoryCheckpointStore is a CheckpointStore that keeps checkpoints in memory, so they only
+// survive for the life of the process.
+type MemoryCheckpointStore struct {
+	mu          sync.Mutex
+	checkpoints map[CheckpointKey]Checkpoint
+}
+
+// NewMemoryCheckpointStore returns an empty MemoryCheckpointStore.
+func NewMemoryCheckpointStore() *MemoryCheckpointStore {
+	return &MemoryCheckpointStore{checkpoints: map[CheckpointKey]Checkpoint{}}
+}
+
+func (s *MemoryCheckpointStore) Load(_ context.Context, key CheckpointKey) (Checkpoint, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	checkpoint, ok := s.checkpoints[key]
+	return cloneCheckpoint(checkpoint), ok, nil
+}
+
+func (s *MemoryCheckpointStore) Save(_ context.Context, key CheckpointKey, checkpoint Checkpoint) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.checkpoints[key] = cloneCheckpoint(checkpoint)
+	return nil
+}
+
+func (s *MemoryCheckpointStore) Delete(_ context.Context, key CheckpointKey) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.checkpoints, key)
+	return nil
+}
+
+func cloneCheckpoint(checkpoint Checkpoint) Checkpoint {
+	if checkpoint.Cursor != nil {
+		cursor := SyncCursor{UpdatedAfter: checkpoint.Cursor.UpdatedAfter, IDs: slices.Clone(checkpoint.Cursor.IDs)}
+		checkpoint.Cursor = &cursor
+	}
+
+	return checkpoint
+}
+
+// FileCheckpointStore is a CheckpointStore that keeps every checkpoint in a single JSON file.
+// The file is replaced atomically on each change, so it is never left half written.
+type FileCheckpointStore struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewFileCheckpointStore returns a FileCheckpointStore backed by the file at path.
+// The file is created on the first Save if it doesn't exist.
+func NewFileCheckpointStore(path string) *FileCheckpointStore {
+	return &FileCheckpointStore{path: path}
+}
+
+func (s *FileCheckpointStore) Load(_ context.Context, key CheckpointKey) (Checkpoint, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	checkpoints, err := s.read()
+	if err != nil {
+		return Checkpoint{}, false, err
+	}
+
+	checkpoint, ok := checkpoints[key.String()]
+	return checkpoint, ok, nil
+}
+
+func (s *FileCheckpointStore) Save(_ context.Context, key CheckpointKey, checkpoint Checkpoint) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	checkpoints, err := s.read()
+	if err != nil {
+		return err
+	}
+
+	checkpoints[key.String()] = checkpoint
+	return s.write(checkpoints)
+}
+
+func (s *FileCheckpointStore) Delete(_ context.Context, key CheckpointKey) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	checkpoints, err := s.read()
+	if err != nil {
+		return err
+	}
+
+	if _, ok := checkpoints[key.String()]; !ok {
+		return nil
+	}
+
+	delete(checkpoints, key.String())
+	return s.write(checkpoints)
+}
+
+func (s *FileCheckpointStore) read() (map[string]Checkpoint, error) {
+	checkpoints := map[string]Checkpoint{}
+
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return checkpoints, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read checkpoints: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &checkpoints); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal checkpoints: %w", err)
+	}
+
+	return checkpoints, nil
+}
+
+func (s *FileCheckpointStore) write(checkpoints map[string]Checkpoint) error {
+	data, err := json.MarshalIndent(checkpoints, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal checkpoints: %w", err)
+	}
+
+	return replaceFile(s.path, data, "checkpoint")
+}
+
+// replaceFile atomically replaces the file at path with data, by writing a temporary file
+// alongside it and renaming it over the original. kind names the file in errors.
+func replaceFile(path string, data []byte, kind string) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create %s file: %w", kind, err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write %s file: %w", kind, err)
+	}
+
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write %s file: %w", kind, err)
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to replace %s file: %w", kind, err)
+	}
+
+	return nil
+}
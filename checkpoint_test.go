@@ -0,0 +1,175 @@
+package donately
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckpointStores(t *testing.T) {
+	stores := map[string]CheckpointStore{
+		"memory": NewMemoryCheckpointStore(),
+		"file":   NewFileCheckpointStore(filepath.Join(t.TempDir(), "checkpoints.json")),
+	}
+
+	for name, store := range stores {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			donations := CheckpointKey{AccountID: "acc_123", Resource: "donations"}
+			sync := CheckpointKey{AccountID: "acc_123", Resource: "sync:donations"}
+
+			_, ok, err := store.Load(ctx, donations)
+			require.NoError(t, err)
+			assert.False(t, ok)
+
+			cursor := &SyncCursor{UpdatedAfter: 200, IDs: []string{"don_2"}}
+			require.NoError(t, store.Save(ctx, donations, Checkpoint{Offset: 40}))
+			require.NoError(t, store.Save(ctx, sync, Checkpoint{Cursor: cursor}))
+
+			// Changes made by the caller after saving don't leak into the store.
+			cursor.IDs[0] = "don_changed"
+
+			checkpoint, ok, err := store.Load(ctx, donations)
+			require.NoError(t, err)
+			assert.True(t, ok)
+			assert.Equal(t, Checkpoint{Offset: 40}, checkpoint)
+
+			checkpoint, ok, err = store.Load(ctx, sync)
+			require.NoError(t, err)
+			assert.True(t, ok)
+			assert.Equal(t, Checkpoint{Cursor: &SyncCursor{UpdatedAfter: 200, IDs: []string{"don_2"}}}, checkpoint)
+
+			require.NoError(t, store.Delete(ctx, donations))
+			require.NoError(t, store.Delete(ctx, donations))
+
+			_, ok, err = store.Load(ctx, donations)
+			require.NoError(t, err)
+			assert.False(t, ok)
+
+			_, ok, err = store.Load(ctx, sync)
+			require.NoError(t, err)
+			assert.True(t, ok)
+		})
+	}
+}
+
+func TestFileCheckpointStoreSurvivesRestart(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "checkpoints.json")
+	key := CheckpointKey{AccountID: "acc_123", Resource: "people"}
+
+	require.NoError(t, NewFileCheckpointStore(path).Save(context.Background(), key, Checkpoint{Offset: 300}))
+
+	checkpoint, ok, err := NewFileCheckpointStore(path).Load(context.Background(), key)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, 300, checkpoint.Offset)
+
+	require.NoError(t, os.WriteFile(path, []byte("not json"), 0o600))
+
+	_, _, err = NewFileCheckpointStore(path).Load(context.Background(), key)
+	assert.ErrorContains(t, err, "failed to unmarshal checkpoints")
+}
+
+func TestAllDonationsResumesFromCheckpoint(t *testing.T) {
+	var requests []string
+	handler := pagedHandler(t, 25, func(i int) Donation {
+		return Donation{ID: fmt.Sprintf("don_%d", i)}
+	}, &requests)
+
+	failing := true
+	server, client := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if failing && r.URL.Query().Get("offset") == "20" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		handler(w, r)
+	})
+	defer server.Close()
+
+	store := NewMemoryCheckpointStore()
+	account := Account{ID: "acc_123"}
+	opts := PageOptions{PageSize: 10, Checkpoints: store}
+
+	count := 0
+	var lastErr error
+	for _, err := range client.AllDonations(context.Background(), account, opts) {
+		if err != nil {
+			lastErr = err
+			break
+		}
+		count++
+	}
+
+	require.ErrorIs(t, lastErr, ErrUnauthorized)
+	assert.Equal(t, 20, count)
+
+	key := CheckpointKey{AccountID: "acc_123", Resource: "donations"}
+	checkpoint, ok, err := store.Load(context.Background(), key)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, 20, checkpoint.Offset)
+
+	failing = false
+	requests = nil
+
+	var ids []string
+	for donation, err := range client.AllDonations(context.Background(), account, opts) {
+		require.NoError(t, err)
+		ids = append(ids, donation.ID)
+	}
+
+	assert.Equal(t, []string{"20:10"}, requests)
+	assert.Len(t, ids, 5)
+	assert.Equal(t, "don_20", ids[0])
+
+	_, ok, err = store.Load(context.Background(), key)
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestSyncDonationsResumesFromCheckpoint(t *testing.T) {
+	store := &donationStore{}
+	store.add("don_1", 100)
+	store.add("don_2", 200)
+	store.add("don_3", 300)
+
+	server, client := setupTestServer(t, store.handler(t))
+	defer server.Close()
+
+	checkpoints := NewMemoryCheckpointStore()
+	account := Account{ID: "acc_123"}
+
+	syncer := client.SyncDonations(account, SyncCursor{})
+	syncer.Checkpoints = checkpoints
+	syncer.PageSize = 1
+
+	for donation, err := range syncer.Changes(context.Background()) {
+		require.NoError(t, err)
+		if donation.ID == "don_2" {
+			break
+		}
+	}
+
+	checkpoint, ok, err := checkpoints.Load(context.Background(), CheckpointKey{AccountID: "acc_123", Resource: "sync:donations"})
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, &SyncCursor{UpdatedAfter: 200, IDs: []string{"don_2"}}, checkpoint.Cursor)
+
+	// A fresh Sync, as after a restart, picks up from the saved cursor.
+	syncer = client.SyncDonations(account, SyncCursor{})
+	syncer.Checkpoints = checkpoints
+
+	assert.Equal(t, []string{"don_3"}, syncIDs(t, syncer))
+	assert.Equal(t, SyncCursor{UpdatedAfter: 300, IDs: []string{"don_3"}}, syncer.Cursor())
+
+	checkpoint, _, err = checkpoints.Load(context.Background(), CheckpointKey{AccountID: "acc_123", Resource: "sync:donations"})
+	require.NoError(t, err)
+	assert.Equal(t, &SyncCursor{UpdatedAfter: 300, IDs: []string{"don_3"}}, checkpoint.Cursor)
+}
@@ -0,0 +1,250 @@
+package donately
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned without contacting the API while the circuit breaker is open.
+var ErrCircuitOpen = errors.New("donately: circuit breaker is open")
+
+const (
+	defaultFailureThreshold = 5
+	defaultCoolDown         = 30 * time.Second
+	defaultHalfOpenProbes   = 1
+)
+
+// CircuitState is the state of the client's circuit breaker.
+type CircuitState int
+
+const (
+	// CircuitClosed lets every request through while counting consecutive failures.
+	CircuitClosed CircuitState = iota
+
+	// CircuitOpen rejects every request with ErrCircuitOpen until the cool-down elapses.
+	CircuitOpen
+
+	// CircuitHalfOpen lets a limited number of probe requests through to decide whether to close again.
+	CircuitHalfOpen
+)
+
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	}
+
+	return "unknown"
+}
+
+// CircuitBreakerSettings configures the circuit breaker enabled by WithCircuitBreaker.
+// Zero values are replaced by their defaults.
+type CircuitBreakerSettings struct {
+	// FailureThreshold is the number of consecutive failures that opens the circuit. Defaults to 5.
+	FailureThreshold int
+
+	// CoolDown is how long the circuit stays open before probing the API again. Defaults to 30 seconds.
+	CoolDown time.Duration
+
+	// HalfOpenProbes is the number of successful probes needed to close the circuit. Defaults to 1.
+	HalfOpenProbes int
+
+	// OnStateChange, if set, is called whenever the circuit changes state.
+	OnStateChange func(from, to CircuitState)
+}
+
+// WithCircuitBreaker returns a ClientOption that guards every attempt of every request with a
+// circuit breaker. Server errors, timeouts and connection failures count as failures, as do
+// requests that outlive the deadline of their context once sent; once the threshold is reached,
+// requests fail fast with ErrCircuitOpen until the API recovers. Canceled requests don't count.
+// If not provided, no circuit breaker is used.
+func WithCircuitBreaker(settings CircuitBreakerSettings) ClientOption {
+	return func(opt *clientOption) {
+		opt.circuitBreaker = &settings
+	}
+}
+
+type circuitBreaker struct {
+	mu       sync.Mutex
+	settings CircuitBreakerSettings
+	state    CircuitState
+	failures int
+	probes   int
+	passed   int
+	openedAt time.Time
+}
+
+func newCircuitBreaker(settings CircuitBreakerSettings) *circuitBreaker {
+	if settings.FailureThreshold <= 0 {
+		settings.FailureThreshold = defaultFailureThreshold
+	}
+
+	if settings.CoolDown <= 0 {
+		settings.CoolDown = defaultCoolDown
+	}
+
+	if settings.HalfOpenProbes <= 0 {
+		settings.HalfOpenProbes = defaultHalfOpenProbes
+	}
+
+	return &circuitBreaker{settings: settings}
+}
+
+// allow reports whether a request may be issued, moving an open circuit to half-open once the cool-down elapses.
+func (b *circuitBreaker) allow() bool {
+	b.mu.Lock()
+
+	var changed func()
+	defer func() {
+		b.mu.Unlock()
+		if changed != nil {
+			changed()
+		}
+	}()
+
+	if b.state == CircuitOpen {
+		if time.Since(b.openedAt) < b.settings.CoolDown {
+			return false
+		}
+
+		changed = b.transition(CircuitHalfOpen)
+	}
+
+	if b.state == CircuitHalfOpen {
+		if b.probes >= b.settings.HalfOpenProbes {
+			return false
+		}
+
+		b.probes++
+	}
+
+	return true
+}
+
+// record updates the circuit with the outcome of a request that was allowed through.
+func (b *circuitBreaker) record(failed bool) {
+	b.mu.Lock()
+
+	var changed func()
+	defer func() {
+		b.mu.Unlock()
+		if changed != nil {
+			changed()
+		}
+	}()
+
+	switch b.state {
+	case CircuitClosed:
+		if !failed {
+			b.failures = 0
+			return
+		}
+
+		b.failures++
+		if b.failures >= b.settings.FailureThreshold {
+			changed = b.transition(CircuitOpen)
+		}
+	case CircuitHalfOpen:
+		if failed {
+			changed = b.transition(CircuitOpen)
+			return
+		}
+
+		b.passed++
+		if b.passed >= b.settings.HalfOpenProbes {
+			changed = b.transition(CircuitClosed)
+		}
+	}
+}
+
+// release gives back the probe slot of a request that was abandoned before its outcome was known.
+func (b *circuitBreaker) release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == CircuitHalfOpen && b.probes > 0 {
+		b.probes--
+	}
+}
+
+// transition moves the circuit to state and returns the callback to run once the lock is released.
+func (b *circuitBreaker) transition(state CircuitState) func() {
+	from := b.state
+	b.state = state
+	b.failures = 0
+	b.probes = 0
+	b.passed = 0
+
+	if state == CircuitOpen {
+		b.openedAt = time.Now()
+	}
+
+	if b.settings.OnStateChange == nil {
+		return nil
+	}
+
+	return func() {
+		b.settings.OnStateChange(from, state)
+	}
+}
+
+func (c *donatelyClient) breakCircuit(next Handler) Handler {
+	return func(ctx context.Context, req *Request) (*Response, error) {
+		if !c.breaker.allow() {
+			return nil, ErrCircuitOpen
+		}
+
+		sent := false
+		resp, err := next(context.WithValue(ctx, sentKey{}, &sent), req)
+
+		switch {
+		case err != nil && sent && errors.Is(ctx.Err(), context.DeadlineExceeded):
+			// The API didn't answer within the caller's deadline, which is how a hung API shows up.
+			c.breaker.record(true)
+		case err != nil && ctx.Err() != nil:
+			// The caller gave up, or its deadline passed before the request was sent.
+			c.breaker.release()
+		default:
+			c.breaker.record(isOutage(resp, err))
+		}
+
+		return resp, err
+	}
+}
+
+// sentKey is the context key under which breakCircuit learns whether an attempt reached the API.
+type sentKey struct{}
+
+// markSent records, for breakCircuit, that the attempt is being sent to the API. Nothing is sent
+// once ctx is done, so it is then left unmarked.
+func markSent(ctx context.Context) {
+	if sent, ok := ctx.Value(sentKey{}).(*bool); ok && ctx.Err() == nil {
+		*sent = true
+	}
+}
+
+// isOutage reports whether a failed attempt points at the API being unavailable,
+// as opposed to a problem with the request itself.
+func isOutage(resp *Response, err error) bool {
+	if err == nil {
+		return false
+	}
+
+	if resp == nil {
+		return true
+	}
+
+	var re retryable
+	if errors.As(err, &re) && re.CanRetry() {
+		return true
+	}
+
+	return resp.StatusCode >= http.StatusInternalServerError
+}
@@ -0,0 +1,177 @@
+package donately
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	var healthy atomic.Bool
+	var calls atomic.Int32
+
+	var mu sync.Mutex
+	var transitions []string
+
+	server, client := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		if !healthy.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		json.NewEncoder(w).Encode(APIResponse{Data: mustMarshal(t, Account{ID: "acc_123"})})
+	}, WithCircuitBreaker(CircuitBreakerSettings{
+		FailureThreshold: 2,
+		CoolDown:         50 * time.Millisecond,
+		HalfOpenProbes:   1,
+		OnStateChange: func(from, to CircuitState) {
+			mu.Lock()
+			defer mu.Unlock()
+			transitions = append(transitions, from.String()+"->"+to.String())
+		},
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+
+	for range 2 {
+		_, err := client.FindAccount(ctx, "acc_123")
+		require.Error(t, err)
+		assert.NotErrorIs(t, err, ErrCircuitOpen)
+	}
+
+	_, err := client.FindAccount(ctx, "acc_123")
+	require.ErrorIs(t, err, ErrCircuitOpen)
+	assert.Equal(t, int32(2), calls.Load())
+
+	time.Sleep(60 * time.Millisecond)
+
+	_, err = client.FindAccount(ctx, "acc_123")
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, ErrCircuitOpen)
+	assert.Equal(t, int32(3), calls.Load())
+
+	_, err = client.FindAccount(ctx, "acc_123")
+	require.ErrorIs(t, err, ErrCircuitOpen)
+
+	time.Sleep(60 * time.Millisecond)
+	healthy.Store(true)
+
+	account, err := client.FindAccount(ctx, "acc_123")
+	require.NoError(t, err)
+	assert.Equal(t, "acc_123", account.ID)
+
+	_, err = client.FindAccount(ctx, "acc_123")
+	require.NoError(t, err)
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, []string{
+		"closed->open",
+		"open->half-open",
+		"half-open->open",
+		"open->half-open",
+		"half-open->closed",
+	}, transitions)
+}
+
+func TestCircuitBreakerIgnoresClientErrors(t *testing.T) {
+	var calls atomic.Int32
+	server, client := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusNotFound)
+	}, WithCircuitBreaker(CircuitBreakerSettings{FailureThreshold: 1}))
+	defer server.Close()
+
+	for range 3 {
+		_, err := client.FindDonation(context.Background(), "don_123", Account{ID: "acc_123"})
+		require.ErrorIs(t, err, ErrNotFound)
+	}
+
+	assert.Equal(t, int32(3), calls.Load())
+}
+
+func TestCircuitBreakerCountsDeadlines(t *testing.T) {
+	var calls atomic.Int32
+	server, client := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+
+		// Hang until the client gives up.
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+		}
+	}, WithCircuitBreaker(CircuitBreakerSettings{FailureThreshold: 2, CoolDown: time.Hour}))
+	defer server.Close()
+
+	for range 2 {
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		_, err := client.FindDonation(ctx, "don_123", Account{ID: "acc_123"})
+		cancel()
+
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+	}
+
+	_, err := client.FindDonation(context.Background(), "don_123", Account{ID: "acc_123"})
+	assert.ErrorIs(t, err, ErrCircuitOpen)
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestCircuitBreakerIgnoresCancellation(t *testing.T) {
+	server, client := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+		}
+	}, WithCircuitBreaker(CircuitBreakerSettings{FailureThreshold: 1, CoolDown: time.Hour}))
+	defer server.Close()
+
+	for range 2 {
+		ctx, cancel := context.WithCancel(context.Background())
+		time.AfterFunc(20*time.Millisecond, cancel)
+
+		_, err := client.FindDonation(ctx, "don_123", Account{ID: "acc_123"})
+		require.ErrorIs(t, err, context.Canceled)
+	}
+
+	// A deadline that passes before the request is sent isn't the API's fault either.
+	ctx, cancel := context.WithTimeout(context.Background(), 0)
+	defer cancel()
+
+	_, err := client.FindDonation(ctx, "don_123", Account{ID: "acc_123"})
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.NotErrorIs(t, err, ErrCircuitOpen)
+
+	_, err = client.FindDonation(ctx, "don_123", Account{ID: "acc_123"})
+	assert.NotErrorIs(t, err, ErrCircuitOpen)
+}
+
+func TestCircuitOpenIsNotRetried(t *testing.T) {
+	var calls atomic.Int32
+	server, client := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusBadGateway)
+	}, WithCircuitBreaker(CircuitBreakerSettings{FailureThreshold: 2, CoolDown: time.Hour}), WithRetry(), WithBackOff(fastBackOff))
+	defer server.Close()
+
+	_, err := client.ListCampaigns(context.Background(), Account{ID: "acc_123"})
+	require.ErrorIs(t, err, ErrCircuitOpen)
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestCircuitBreakerDefaults(t *testing.T) {
+	breaker := newCircuitBreaker(CircuitBreakerSettings{})
+
+	assert.Equal(t, defaultFailureThreshold, breaker.settings.FailureThreshold)
+	assert.Equal(t, defaultCoolDown, breaker.settings.CoolDown)
+	assert.Equal(t, defaultHalfOpenProbes, breaker.settings.HalfOpenProbes)
+	assert.Equal(t, CircuitClosed, breaker.state)
+}
@@ -10,12 +10,18 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"iter"
+	"log/slog"
 	"net/http"
 	"net/url"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/cenkalti/backoff/v5"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // Client defines the interface for interacting with the Donately API.
@@ -28,9 +34,23 @@ type Client interface {
 	// The offset and limit parameters control pagination (0 values disable pagination).
 	ListPeople(context.Context, Account, int, int) ([]Person, error)
 
+	// ListPeoplePage retrieves a single page of people for the given account along with its pagination metadata.
+	ListPeoplePage(context.Context, Account, int, int) (Page[Person], error)
+
+	// SearchPeople retrieves a page of people for the given account matching the query.
+	SearchPeople(context.Context, Account, PeopleQuery) (Page[Person], error)
+
+	// FindPersonByEmail retrieves the person with the given email address for the given account.
+	// If there is no such person, the returned error is a *PersonNotFoundError.
+	FindPersonByEmail(context.Context, string, Account) (Person, error)
+
 	// FindPerson retrieves a specific person by ID for the given account.
 	FindPerson(context.Context, string, Account) (Person, error)
 
+	// FindPeople retrieves the people with the given IDs for the given account, running several lookups
+	// at once (see WithBatchConcurrency). Results are returned in the order of the IDs.
+	FindPeople(context.Context, []string, Account) []Result[Person]
+
 	// Me retrieves the authenticated user's person record.
 	Me(context.Context) (Person, error)
 
@@ -41,12 +61,22 @@ type Client interface {
 	// The offset and limit parameters control pagination (0 values disable pagination).
 	ListDonations(context.Context, Account, int, int) ([]Donation, error)
 
+	// ListDonationsPage retrieves a single page of donations for the given account along with its pagination metadata.
+	ListDonationsPage(context.Context, Account, int, int) (Page[Donation], error)
+
+	// SearchDonations retrieves a page of donations for the given account matching the query.
+	SearchDonations(context.Context, Account, DonationQuery) (Page[Donation], error)
+
 	// ListMyDonations retrieves donations for the authenticated user.
 	ListMyDonations(context.Context) ([]Donation, error)
 
 	// FindDonation retrieves a specific donation by ID for the given account.
 	FindDonation(context.Context, string, Account) (Donation, error)
 
+	// FindDonations retrieves the donations with the given IDs for the given account, running several lookups
+	// at once (see WithBatchConcurrency). Results are returned in the order of the IDs.
+	FindDonations(context.Context, []string, Account) []Result[Donation]
+
 	// SaveDonation creates or updates a donation record. If the donation has no ID, it will be created.
 	SaveDonation(context.Context, Donation) (Donation, error)
 
@@ -59,18 +89,34 @@ type Client interface {
 	// ListSubscriptions retrieves all subscriptions for the given account.
 	ListSubscriptions(context.Context, Account) ([]Subscription, error)
 
+	// ListSubscriptionsPage retrieves a single page of subscriptions for the given account along with its pagination metadata.
+	ListSubscriptionsPage(context.Context, Account, int, int) (Page[Subscription], error)
+
+	// SearchSubscriptions retrieves a page of subscriptions for the given account matching the query.
+	SearchSubscriptions(context.Context, Account, SubscriptionQuery) (Page[Subscription], error)
+
 	// ListMySubscriptions retrieves subscriptions for the authenticated user.
 	ListMySubscriptions(context.Context) ([]Subscription, error)
 
 	// FindSubscription retrieves a specific subscription by ID for the given account.
 	FindSubscription(context.Context, string, Account) (Subscription, error)
 
+	// FindSubscriptions retrieves the subscriptions with the given IDs for the given account, running several lookups
+	// at once (see WithBatchConcurrency). Results are returned in the order of the IDs.
+	FindSubscriptions(context.Context, []string, Account) []Result[Subscription]
+
 	// SaveSubscription creates or updates a subscription record. If the subscription has no ID, it will be created.
 	SaveSubscription(context.Context, Subscription) (Subscription, error)
 
 	// ListCampaigns retrieves all campaigns for the given account.
 	ListCampaigns(context.Context, Account) ([]Campaign, error)
 
+	// ListCampaignsPage retrieves a single page of campaigns for the given account along with its pagination metadata.
+	ListCampaignsPage(context.Context, Account, int, int) (Page[Campaign], error)
+
+	// SearchCampaigns retrieves a page of campaigns for the given account matching the query.
+	SearchCampaigns(context.Context, Account, CampaignQuery) (Page[Campaign], error)
+
 	// FindCampaign retrieves a specific campaign by ID for the given account.
 	FindCampaign(context.Context, string, Account) (Campaign, error)
 
@@ -79,6 +125,30 @@ type Client interface {
 
 	// DeleteCampaign deletes the specified campaign.
 	DeleteCampaign(context.Context, Campaign) error
+
+	// AllPeople returns an iterator over every person for the given account, fetching pages as needed.
+	AllPeople(context.Context, Account, PageOptions) iter.Seq2[Person, error]
+
+	// AllDonations returns an iterator over every donation for the given account, fetching pages as needed.
+	AllDonations(context.Context, Account, PageOptions) iter.Seq2[Donation, error]
+
+	// AllSubscriptions returns an iterator over every subscription for the given account, fetching pages as needed.
+	AllSubscriptions(context.Context, Account, PageOptions) iter.Seq2[Subscription, error]
+
+	// AllCampaigns returns an iterator over every campaign for the given account, fetching pages as needed.
+	AllCampaigns(context.Context, Account, PageOptions) iter.Seq2[Campaign, error]
+
+	// SyncPeople returns a Sync over the people for the given account created or changed since the cursor.
+	SyncPeople(Account, SyncCursor) *Sync[Person]
+
+	// SyncDonations returns a Sync over the donations for the given account created or changed since the cursor.
+	SyncDonations(Account, SyncCursor) *Sync[Donation]
+
+	// SyncSubscriptions returns a Sync over the subscriptions for the given account created or changed since the cursor.
+	SyncSubscriptions(Account, SyncCursor) *Sync[Subscription]
+
+	// SyncCampaigns returns a Sync over the campaigns for the given account created or changed since the cursor.
+	SyncCampaigns(Account, SyncCursor) *Sync[Campaign]
 }
 
 type clientOption struct {
@@ -86,12 +156,33 @@ type clientOption struct {
 	baseURL            string
 	donatelyAPIVersion string
 	doRetry            bool
-	debug              bool
+	newBackOff         func() backoff.BackOff
+	maxAttempts        uint
+	maxElapsedTime     time.Duration
+	newIdempotencyKey  func() string
+	httpClient         *http.Client
+	transport          http.RoundTripper
+	timeout            time.Duration
+	middleware         []Middleware
+	logger             *slog.Logger
+	logLevel           slog.Level
+	errorLogLevel      slog.Level
+	disableRedaction   bool
+	rateLimit          float64
+	rateLimitBurst     int
+	circuitBreaker     *CircuitBreakerSettings
+	tracerProvider     trace.TracerProvider
+	meterProvider      metric.MeterProvider
+	batchConcurrency   int
 }
 
 type donatelyClient struct {
-	opts   clientOption
-	client *http.Client
+	opts      clientOption
+	client    *http.Client
+	handler   Handler
+	limiter   *rateLimiter
+	breaker   *circuitBreaker
+	telemetry *telemetry
 }
 
 // ClientOption defines a function type for configuring client options.
@@ -105,6 +196,15 @@ type APIResponse struct {
 	Message   string          `json:"message"`
 	Code      string          `json:"code"`
 	RequestID string          `json:"request_id"`
+	Summary   *Summary        `json:"summary,omitempty"`
+}
+
+// Summary holds the pagination metadata Donately returns alongside list responses.
+type Summary struct {
+	TotalCount int  `json:"total_count"`
+	Offset     int  `json:"offset"`
+	Limit      int  `json:"limit"`
+	HasMore    bool `json:"has_more"`
 }
 
 // WithAPIKey returns a ClientOption that sets the API key for authentication.
@@ -122,7 +222,9 @@ func WithBaseURL(url string) ClientOption {
 	}
 }
 
-// WithRetry returns a ClientOption that enables retries (when applicable) for the Donately API.
+// WithRetry returns a ClientOption that enables retries of transient failures (5xx responses,
+// throttling, timeouts and dropped connections) for every request made to the Donately API.
+// Non-idempotent requests are only retried when the API is known not to have processed them.
 // If not provided, defaults to false.
 func WithRetry() ClientOption {
 	return func(opt *clientOption) {
@@ -130,6 +232,31 @@ func WithRetry() ClientOption {
 	}
 }
 
+// WithHTTPClient returns a ClientOption that sets the HTTP client used to reach the Donately API.
+// The client is copied, so WithTransport and WithTimeout never modify the provided value.
+// If not provided, a zero-value http.Client is used.
+func WithHTTPClient(client *http.Client) ClientOption {
+	return func(opt *clientOption) {
+		opt.httpClient = client
+	}
+}
+
+// WithTransport returns a ClientOption that sets the http.RoundTripper used to issue requests,
+// overriding the transport of the HTTP client.
+func WithTransport(transport http.RoundTripper) ClientOption {
+	return func(opt *clientOption) {
+		opt.transport = transport
+	}
+}
+
+// WithTimeout returns a ClientOption that limits the time taken by each attempt of a request,
+// overriding the timeout of the HTTP client. Timed out attempts are retried when retries are enabled.
+func WithTimeout(timeout time.Duration) ClientOption {
+	return func(opt *clientOption) {
+		opt.timeout = timeout
+	}
+}
+
 // NewDonatelyClient creates a new Donately API client with the provided options.
 // An API key must be provided using WithAPIKey, otherwise an error is returned.
 // The client uses "https://api.com/v2" as the default base URL.
@@ -137,6 +264,11 @@ func NewDonatelyClient(options ...ClientOption) (Client, error) {
 	clientOptions := clientOption{
 		baseURL:            "https://api.donately.com/v2",
 		donatelyAPIVersion: "2018-04-01",
+		maxAttempts:        defaultMaxAttempts,
+		maxElapsedTime:     defaultMaxElapsedTime,
+		logLevel:           slog.LevelDebug,
+		errorLogLevel:      slog.LevelWarn,
+		batchConcurrency:   defaultBatchConcurrency,
 	}
 
 	for _, option := range options {
@@ -151,31 +283,49 @@ func NewDonatelyClient(options ...ClientOption) (Client, error) {
 		return &donatelyClient{}, errors.New("missing base URL!")
 	}
 
-	return &donatelyClient{
-		opts:   clientOptions,
-		client: &http.Client{},
-	}, nil
-}
+	httpClient := &http.Client{}
+	if clientOptions.httpClient != nil {
+		clone := *clientOptions.httpClient
+		httpClient = &clone
+	}
 
-type retryable interface {
-	CanRetry() bool
-}
+	if clientOptions.transport != nil {
+		httpClient.Transport = clientOptions.transport
+	}
 
-type retryableError struct {
-	Err      error
-	canRetry bool
-}
+	if clientOptions.timeout > 0 {
+		httpClient.Timeout = clientOptions.timeout
+	}
 
-func (e retryableError) Error() string {
-	return e.Err.Error()
-}
+	telemetry, err := newTelemetry(clientOptions.tracerProvider, clientOptions.meterProvider)
+	if err != nil {
+		return &donatelyClient{}, fmt.Errorf("failed to set up telemetry: %w", err)
+	}
 
-func (e retryableError) Unwrap() error {
-	return e.Err
-}
+	c := &donatelyClient{
+		opts:      clientOptions,
+		client:    httpClient,
+		telemetry: telemetry,
+	}
+
+	middleware := slices.Clone(clientOptions.middleware)
+	if clientOptions.circuitBreaker != nil {
+		c.breaker = newCircuitBreaker(*clientOptions.circuitBreaker)
+		middleware = append(middleware, c.breakCircuit)
+	}
+
+	if clientOptions.rateLimit > 0 {
+		c.limiter = newRateLimiter(clientOptions.rateLimit, clientOptions.rateLimitBurst)
+		middleware = append(middleware, c.limitRate)
+	}
+
+	if clientOptions.logger != nil {
+		middleware = append(middleware, c.logRequests)
+	}
+
+	c.handler = chain(c.send, middleware...)
 
-func (e retryableError) CanRetry() bool {
-	return e.canRetry
+	return c, nil
 }
 
 func (c *donatelyClient) makeRequest(ctx context.Context, method, endpoint string, body any) (*APIResponse, error) {
@@ -183,27 +333,18 @@ func (c *donatelyClient) makeRequest(ctx context.Context, method, endpoint strin
 }
 
 func (c *donatelyClient) makeRequestWithContentType(ctx context.Context, method, endpoint string, body any, contentType string) (*APIResponse, error) {
-	var reqBody io.Reader
-	if body != nil {
-		switch contentType {
-		case "application/x-www-form-urlencoded":
-			if formData, ok := body.(url.Values); ok {
-				reqBody = strings.NewReader(formData.Encode())
-			} else {
-				return nil, fmt.Errorf("body must be url.Values for form-encoded requests")
-			}
-		default:
-			jsonBody, err := json.Marshal(body)
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal request body: %w", err)
-			}
-			reqBody = bytes.NewReader(jsonBody)
-		}
+	payload, err := encodeRequestBody(body, contentType)
+	if err != nil {
+		return nil, err
 	}
 
-	req, err := http.NewRequestWithContext(ctx, method, c.opts.baseURL+endpoint, reqBody)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+	req := Request{
+		Operation:   operationFromContext(ctx),
+		Method:      method,
+		Endpoint:    endpoint,
+		ContentType: contentType,
+		Header:      http.Header{},
+		Body:        payload,
 	}
 
 	req.Header.Set("Donately-Version", c.opts.donatelyAPIVersion)
@@ -211,12 +352,111 @@ func (c *donatelyClient) makeRequestWithContentType(ctx context.Context, method,
 	req.Header.Set("Content-Type", contentType)
 	req.Header.Set("Accept", "application/json")
 
-	requestLine := fmt.Sprintf("%s %s %s", req.Method, req.URL.RequestURI(), req.Proto)
+	idempotencyKey := c.idempotencyKey(ctx, req)
+	if idempotencyKey != "" {
+		req.Header.Set(idempotencyKeyHeader, idempotencyKey)
+	}
+
+	var info callInfo
+	var end func(callInfo, error)
+	if c.telemetry != nil {
+		ctx, end = c.telemetry.start(ctx, req)
+	}
+
+	resp, err := c.execute(ctx, req, idempotencyKey, &info)
+
+	if end != nil {
+		end(info, err)
+	}
+
+	return resp, err
+}
+
+// execute runs req, retrying failed attempts as configured.
+func (c *donatelyClient) execute(ctx context.Context, req Request, idempotencyKey string, info *callInfo) (*APIResponse, error) {
+	if !c.opts.doRetry && c.limiter == nil {
+		return c.doRequest(ctx, req, info)
+	}
+
+	b := c.newBackOff()
+	operation := func() (*APIResponse, error) {
+		resp, err := c.doRequest(ctx, req, info)
+		if err != nil && !c.canRetry(ctx, req.Method, idempotencyKey, err) {
+			return nil, backoff.Permanent(err)
+		}
+
+		b.observe(err)
+		return resp, err
+	}
+
+	return backoff.Retry(ctx, operation, c.retryOptions(b)...)
+}
+
+func encodeRequestBody(body any, contentType string) ([]byte, error) {
+	if body == nil {
+		return nil, nil
+	}
+
+	switch contentType {
+	case "application/x-www-form-urlencoded":
+		if formData, ok := body.(url.Values); ok {
+			return []byte(formData.Encode()), nil
+		}
+
+		return nil, fmt.Errorf("body must be url.Values for form-encoded requests")
+	default:
+		jsonBody, err := json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal request body: %w", err)
+		}
+
+		return jsonBody, nil
+	}
+}
+
+// doRequest runs a single attempt of req through the middleware chain, recording its outcome in info.
+// Each attempt gets its own copy of the headers so middleware can't leak changes between attempts.
+func (c *donatelyClient) doRequest(ctx context.Context, req Request, info *callInfo) (*APIResponse, error) {
+	info.attempts++
+
+	req.Header = req.Header.Clone()
+	req.Attempt = info.attempts
+
+	resp, err := c.handler(ctx, &req)
+
+	info.statusCode = 0
+	if resp != nil {
+		info.statusCode = resp.StatusCode
+	}
+	info.requestID = responseRequestID(resp, err)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if resp == nil || resp.APIResponse == nil {
+		return nil, errors.New("no API response returned")
+	}
+
+	return resp.APIResponse, nil
+}
+
+// send is the innermost Handler; it issues the HTTP request and decodes the response envelope.
+func (c *donatelyClient) send(ctx context.Context, r *Request) (*Response, error) {
+	var reqBody io.Reader
+	if r.Body != nil {
+		reqBody = bytes.NewReader(r.Body)
+	}
 
-	if c.opts.debug {
-		fmt.Println("Issuing request", requestLine)
+	req, err := http.NewRequestWithContext(ctx, r.Method, c.opts.baseURL+r.Endpoint, reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
+	req.Header = r.Header.Clone()
+
+	markSent(ctx)
+
 	resp, err := c.client.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to make request: %w", err)
@@ -228,6 +468,12 @@ func (c *donatelyClient) makeRequestWithContentType(ctx context.Context, method,
 		return nil, fmt.Errorf("failed to read response body: %w", err)
 	}
 
+	response := &Response{
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header,
+		Body:       respBody,
+	}
+
 	var apiResp APIResponse
 	if err := json.Unmarshal(respBody, &apiResp); err != nil {
 		rawBody := string(respBody)
@@ -235,24 +481,32 @@ func (c *donatelyClient) makeRequestWithContentType(ctx context.Context, method,
 		errorReturned := fmt.Errorf("failed to unmarshal response: %w", err)
 
 		if "retry later" == strings.ToLower(strings.TrimSpace(rawBody)) {
-			return nil, retryableError{Err: errorReturned, canRetry: true}
+			return response, retryableError{Err: errorReturned, canRetry: true}
+		}
+
+		if resp.StatusCode >= 400 {
+			return response, newAPIError(response, APIResponse{})
 		}
 
-		return nil, errorReturned
+		return response, errorReturned
 	}
 
+	response.APIResponse = &apiResp
+
 	if apiResp.Type != "" && apiResp.Message != "" && apiResp.Code != "" {
-		return nil, fmt.Errorf("API error: %s - (%s) %s", apiResp.Code, apiResp.Type, apiResp.Message)
+		return response, newAPIError(response, apiResp)
 	}
 
 	if resp.StatusCode >= 400 {
-		return nil, fmt.Errorf("HTTP error: %d (Raw Response: %v)", resp.StatusCode, apiResp)
+		return response, newAPIError(response, apiResp)
 	}
 
-	return &apiResp, nil
+	return response, nil
 }
 
 func (c *donatelyClient) FindAccount(ctx context.Context, id string) (Account, error) {
+	ctx = withOperation(ctx, "FindAccount")
+
 	endpoint := fmt.Sprintf("/accounts/%s", url.PathEscape(id))
 
 	resp, err := c.makeRequest(ctx, http.MethodGet, endpoint, nil)
@@ -269,31 +523,25 @@ func (c *donatelyClient) FindAccount(ctx context.Context, id string) (Account, e
 }
 
 func (c *donatelyClient) ListPeople(ctx context.Context, account Account, offset, limit int) ([]Person, error) {
-	params := url.Values{}
-	params.Set("account_id", account.ID)
-
-	if offset > 0 {
-		params.Set("offset", strconv.Itoa(offset))
-	}
-
-	if limit > 0 {
-		params.Set("limit", strconv.Itoa(limit))
-	}
+	page, err := listPage[Person](withOperation(ctx, "ListPeople"), c, "/people", account, nil, offset, limit)
+	return page.Items, err
+}
 
-	resp, err := c.makeRequest(ctx, http.MethodGet, "/people?"+params.Encode(), nil)
-	if err != nil {
-		return nil, err
-	}
+func (c *donatelyClient) ListPeoplePage(ctx context.Context, account Account, offset, limit int) (Page[Person], error) {
+	return listPage[Person](withOperation(ctx, "ListPeoplePage"), c, "/people", account, nil, offset, limit)
+}
 
-	var people []Person
-	if err := json.Unmarshal(resp.Data, &people); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal people: %w", err)
+func (c *donatelyClient) SearchPeople(ctx context.Context, account Account, query PeopleQuery) (Page[Person], error) {
+	if err := query.validate(); err != nil {
+		return Page[Person]{}, err
 	}
 
-	return people, nil
+	return listPage[Person](withOperation(ctx, "SearchPeople"), c, "/people", account, query.values(), query.Offset, query.Limit)
 }
 
 func (c *donatelyClient) FindPerson(ctx context.Context, id string, account Account) (Person, error) {
+	ctx = withOperation(ctx, "FindPerson")
+
 	endpoint := fmt.Sprintf("/people/%s", url.PathEscape(id))
 
 	params := url.Values{}
@@ -312,7 +560,32 @@ func (c *donatelyClient) FindPerson(ctx context.Context, id string, account Acco
 	return person, nil
 }
 
+func (c *donatelyClient) FindPersonByEmail(ctx context.Context, email string, account Account) (Person, error) {
+	ctx = withOperation(ctx, "FindPersonByEmail")
+
+	email = strings.TrimSpace(email)
+	if email == "" {
+		return Person{}, fmt.Errorf("%w: missing email", ErrValidation)
+	}
+
+	page, err := listPage[Person](ctx, c, "/people", account, PeopleQuery{Email: email}.values(), 0, 0)
+	if err != nil {
+		return Person{}, err
+	}
+
+	// The API may match loosely, so only an exact (case-insensitive) match counts.
+	for _, person := range page.Items {
+		if strings.EqualFold(person.Email, email) {
+			return person, nil
+		}
+	}
+
+	return Person{}, &PersonNotFoundError{Email: email, AccountID: account.ID}
+}
+
 func (c *donatelyClient) Me(ctx context.Context) (Person, error) {
+	ctx = withOperation(ctx, "Me")
+
 	resp, err := c.makeRequest(ctx, http.MethodGet, "/me", nil)
 	if err != nil {
 		return Person{}, err
@@ -327,6 +600,8 @@ func (c *donatelyClient) Me(ctx context.Context) (Person, error) {
 }
 
 func (c *donatelyClient) SavePerson(ctx context.Context, person Person) (Person, error) {
+	ctx = withOperation(ctx, "SavePerson")
+
 	var endpoint string
 
 	if person.ID == "" {
@@ -336,7 +611,7 @@ func (c *donatelyClient) SavePerson(ctx context.Context, person Person) (Person,
 	}
 
 	if len(person.Accounts) == 0 || person.Accounts[0].ID == "" {
-		return Person{}, errors.New("missing account information")
+		return Person{}, fmt.Errorf("%w: missing account information", ErrValidation)
 	}
 
 	accountId := person.Accounts[0].ID
@@ -390,31 +665,25 @@ func (c *donatelyClient) SavePerson(ctx context.Context, person Person) (Person,
 }
 
 func (c *donatelyClient) ListDonations(ctx context.Context, account Account, offset, limit int) ([]Donation, error) {
-	params := url.Values{}
-	params.Set("account_id", account.ID)
-
-	if offset > 0 {
-		params.Set("offset", strconv.Itoa(offset))
-	}
-
-	if limit > 0 {
-		params.Set("limit", strconv.Itoa(limit))
-	}
+	page, err := listPage[Donation](withOperation(ctx, "ListDonations"), c, "/donations", account, nil, offset, limit)
+	return page.Items, err
+}
 
-	resp, err := c.makeRequest(ctx, http.MethodGet, "/donations?"+params.Encode(), nil)
-	if err != nil {
-		return nil, err
-	}
+func (c *donatelyClient) ListDonationsPage(ctx context.Context, account Account, offset, limit int) (Page[Donation], error) {
+	return listPage[Donation](withOperation(ctx, "ListDonationsPage"), c, "/donations", account, nil, offset, limit)
+}
 
-	var donations []Donation
-	if err := json.Unmarshal(resp.Data, &donations); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal donations: %w", err)
+func (c *donatelyClient) SearchDonations(ctx context.Context, account Account, query DonationQuery) (Page[Donation], error) {
+	if err := query.validate(); err != nil {
+		return Page[Donation]{}, err
 	}
 
-	return donations, nil
+	return listPage[Donation](withOperation(ctx, "SearchDonations"), c, "/donations", account, query.values(), query.Offset, query.Limit)
 }
 
 func (c *donatelyClient) ListMyDonations(ctx context.Context) ([]Donation, error) {
+	ctx = withOperation(ctx, "ListMyDonations")
+
 	resp, err := c.makeRequest(ctx, http.MethodGet, "/me/donations", nil)
 	if err != nil {
 		return nil, err
@@ -429,6 +698,8 @@ func (c *donatelyClient) ListMyDonations(ctx context.Context) ([]Donation, error
 }
 
 func (c *donatelyClient) FindDonation(ctx context.Context, id string, account Account) (Donation, error) {
+	ctx = withOperation(ctx, "FindDonation")
+
 	params := url.Values{}
 	params.Set("account_id", account.ID)
 
@@ -447,6 +718,8 @@ func (c *donatelyClient) FindDonation(ctx context.Context, id string, account Ac
 }
 
 func (c *donatelyClient) SaveDonation(ctx context.Context, donation Donation) (Donation, error) {
+	ctx = withOperation(ctx, "SaveDonation")
+
 	var endpoint string
 
 	if donation.ID == "" {
@@ -456,7 +729,7 @@ func (c *donatelyClient) SaveDonation(ctx context.Context, donation Donation) (D
 	}
 
 	if donation.Account.ID == "" {
-		return Donation{}, errors.New("missing account information")
+		return Donation{}, fmt.Errorf("%w: missing account information", ErrValidation)
 	}
 
 	params := url.Values{}
@@ -486,27 +759,23 @@ func (c *donatelyClient) SaveDonation(ctx context.Context, donation Donation) (D
 	if donation.Status != "" {
 		params.Set("status", donation.Status)
 	}
+	if donation.DonationDate > 0 {
+		params.Set("donation_date", strconv.FormatInt(donation.DonationDate, 10))
+	}
+	if donation.Currency != "" {
+		params.Set("currency", donation.Currency)
+	}
+	if donation.TrackingCodes != "" {
+		params.Set("tracking_codes", donation.TrackingCodes)
+	}
 
 	if len(params) > 0 {
 		endpoint += "?" + params.Encode()
 	}
 
 	resp, err := c.makeRequest(ctx, http.MethodPost, endpoint, nil)
-
-	if c.opts.doRetry {
-		re, ok := err.(retryable)
-		if ok && re.CanRetry() {
-			operation := func() (*APIResponse, error) {
-				return c.makeRequest(ctx, http.MethodPost, endpoint, nil)
-			}
-			resp, err = backoff.Retry(ctx, operation, backoff.WithBackOff(backoff.NewExponentialBackOff()))
-			if err != nil {
-				return Donation{}, err
-
-			}
-		} else {
-			return Donation{}, err
-		}
+	if err != nil {
+		return Donation{}, err
 	}
 
 	var savedDonation Donation
@@ -518,10 +787,12 @@ func (c *donatelyClient) SaveDonation(ctx context.Context, donation Donation) (D
 }
 
 func (c *donatelyClient) RefundDonation(ctx context.Context, donation Donation, reason string) error {
+	ctx = withOperation(ctx, "RefundDonation")
+
 	endpoint := fmt.Sprintf("/donations/%s/refund", url.PathEscape(donation.ID))
 
 	if donation.Account.ID == "" {
-		return errors.New("missing account information")
+		return fmt.Errorf("%w: missing account information", ErrValidation)
 	}
 
 	formData := url.Values{}
@@ -533,6 +804,8 @@ func (c *donatelyClient) RefundDonation(ctx context.Context, donation Donation,
 }
 
 func (c *donatelyClient) SendDonationReceipt(ctx context.Context, donation Donation) error {
+	ctx = withOperation(ctx, "SendDonationReceipt")
+
 	endpoint := fmt.Sprintf("/donations/%s/receipt", url.PathEscape(donation.ID))
 	_, err := c.makeRequest(ctx, http.MethodPost, endpoint, nil)
 	return err
@@ -540,23 +813,25 @@ func (c *donatelyClient) SendDonationReceipt(ctx context.Context, donation Donat
 
 // Subscriptions operations
 func (c *donatelyClient) ListSubscriptions(ctx context.Context, account Account) ([]Subscription, error) {
-	params := url.Values{}
-	params.Set("account_id", account.ID)
+	page, err := listPage[Subscription](withOperation(ctx, "ListSubscriptions"), c, "/subscriptions", account, nil, 0, 0)
+	return page.Items, err
+}
 
-	resp, err := c.makeRequest(ctx, http.MethodGet, "/subscriptions?"+params.Encode(), nil)
-	if err != nil {
-		return nil, err
-	}
+func (c *donatelyClient) ListSubscriptionsPage(ctx context.Context, account Account, offset, limit int) (Page[Subscription], error) {
+	return listPage[Subscription](withOperation(ctx, "ListSubscriptionsPage"), c, "/subscriptions", account, nil, offset, limit)
+}
 
-	var subscriptions []Subscription
-	if err := json.Unmarshal(resp.Data, &subscriptions); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal subscriptions: %w", err)
+func (c *donatelyClient) SearchSubscriptions(ctx context.Context, account Account, query SubscriptionQuery) (Page[Subscription], error) {
+	if err := query.validate(); err != nil {
+		return Page[Subscription]{}, err
 	}
 
-	return subscriptions, nil
+	return listPage[Subscription](withOperation(ctx, "SearchSubscriptions"), c, "/subscriptions", account, query.values(), query.Offset, query.Limit)
 }
 
 func (c *donatelyClient) ListMySubscriptions(ctx context.Context) ([]Subscription, error) {
+	ctx = withOperation(ctx, "ListMySubscriptions")
+
 	resp, err := c.makeRequest(ctx, http.MethodGet, "/me/subscriptions", nil)
 	if err != nil {
 		return nil, err
@@ -571,6 +846,8 @@ func (c *donatelyClient) ListMySubscriptions(ctx context.Context) ([]Subscriptio
 }
 
 func (c *donatelyClient) FindSubscription(ctx context.Context, id string, account Account) (Subscription, error) {
+	ctx = withOperation(ctx, "FindSubscription")
+
 	endpoint := fmt.Sprintf("/subscriptions/%s", url.PathEscape(id))
 
 	params := url.Values{}
@@ -590,6 +867,8 @@ func (c *donatelyClient) FindSubscription(ctx context.Context, id string, accoun
 }
 
 func (c *donatelyClient) SaveSubscription(ctx context.Context, subscription Subscription) (Subscription, error) {
+	ctx = withOperation(ctx, "SaveSubscription")
+
 	var endpoint string
 
 	if subscription.ID == "" {
@@ -612,23 +891,25 @@ func (c *donatelyClient) SaveSubscription(ctx context.Context, subscription Subs
 }
 
 func (c *donatelyClient) ListCampaigns(ctx context.Context, account Account) ([]Campaign, error) {
-	params := url.Values{}
-	params.Set("account_id", account.ID)
+	page, err := listPage[Campaign](withOperation(ctx, "ListCampaigns"), c, "/campaigns", account, nil, 0, 0)
+	return page.Items, err
+}
 
-	resp, err := c.makeRequest(ctx, http.MethodGet, "/campaigns?"+params.Encode(), nil)
-	if err != nil {
-		return nil, err
-	}
+func (c *donatelyClient) ListCampaignsPage(ctx context.Context, account Account, offset, limit int) (Page[Campaign], error) {
+	return listPage[Campaign](withOperation(ctx, "ListCampaignsPage"), c, "/campaigns", account, nil, offset, limit)
+}
 
-	var campaigns []Campaign
-	if err := json.Unmarshal(resp.Data, &campaigns); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal campaigns: %w", err)
+func (c *donatelyClient) SearchCampaigns(ctx context.Context, account Account, query CampaignQuery) (Page[Campaign], error) {
+	if err := query.validate(); err != nil {
+		return Page[Campaign]{}, err
 	}
 
-	return campaigns, nil
+	return listPage[Campaign](withOperation(ctx, "SearchCampaigns"), c, "/campaigns", account, query.values(), query.Offset, query.Limit)
 }
 
 func (c *donatelyClient) FindCampaign(ctx context.Context, id string, account Account) (Campaign, error) {
+	ctx = withOperation(ctx, "FindCampaign")
+
 	endpoint := fmt.Sprintf("/campaigns/%s", url.PathEscape(id))
 
 	params := url.Values{}
@@ -648,6 +929,8 @@ func (c *donatelyClient) FindCampaign(ctx context.Context, id string, account Ac
 }
 
 func (c *donatelyClient) SaveCampaign(ctx context.Context, campaign Campaign) (Campaign, error) {
+	ctx = withOperation(ctx, "SaveCampaign")
+
 	var endpoint string
 
 	if campaign.ID == "" {
@@ -670,6 +953,8 @@ func (c *donatelyClient) SaveCampaign(ctx context.Context, campaign Campaign) (C
 }
 
 func (c *donatelyClient) DeleteCampaign(ctx context.Context, campaign Campaign) error {
+	ctx = withOperation(ctx, "DeleteCampaign")
+
 	endpoint := fmt.Sprintf("/campaigns/%s", url.PathEscape(campaign.ID))
 	_, err := c.makeRequest(ctx, http.MethodDelete, endpoint, nil)
 	return err
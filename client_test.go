@@ -6,8 +6,11 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/cenkalti/backoff/v5"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -83,16 +86,59 @@ func TestClientOptions(t *testing.T) {
 		WithRetry()(&opts)
 		assert.True(t, opts.doRetry)
 	})
+
+	t.Run("WithHTTPClient sets HTTP client", func(t *testing.T) {
+		opts := clientOption{}
+		httpClient := &http.Client{}
+		WithHTTPClient(httpClient)(&opts)
+		assert.Same(t, httpClient, opts.httpClient)
+	})
+
+	t.Run("WithTransport sets transport", func(t *testing.T) {
+		opts := clientOption{}
+		WithTransport(http.DefaultTransport)(&opts)
+		assert.Equal(t, http.DefaultTransport, opts.transport)
+	})
+
+	t.Run("WithTimeout sets timeout", func(t *testing.T) {
+		opts := clientOption{}
+		WithTimeout(time.Second)(&opts)
+		assert.Equal(t, time.Second, opts.timeout)
+	})
+
+	t.Run("WithMaxAttempts sets max attempts", func(t *testing.T) {
+		opts := clientOption{}
+		WithMaxAttempts(3)(&opts)
+		assert.Equal(t, uint(3), opts.maxAttempts)
+
+		WithMaxAttempts(0)(&opts)
+		assert.Equal(t, uint(1), opts.maxAttempts)
+	})
+
+	t.Run("WithMaxElapsedTime sets max elapsed time", func(t *testing.T) {
+		opts := clientOption{}
+		WithMaxElapsedTime(time.Second)(&opts)
+		assert.Equal(t, time.Second, opts.maxElapsedTime)
+	})
+
+	t.Run("WithBackOff sets backoff factory", func(t *testing.T) {
+		opts := clientOption{}
+		WithBackOff(fastBackOff)(&opts)
+		require.NotNil(t, opts.newBackOff)
+		assert.IsType(t, &backoff.ConstantBackOff{}, opts.newBackOff())
+	})
 }
 
-func setupTestServer(t *testing.T, handler http.HandlerFunc) (*httptest.Server, Client) {
+func setupTestServer(t *testing.T, handler http.HandlerFunc, options ...ClientOption) (*httptest.Server, Client) {
 	server := httptest.NewServer(handler)
 	t.Cleanup(server.Close)
 
-	client, err := NewDonatelyClient(
+	options = append([]ClientOption{
 		WithAPIKey("test-api-key"),
 		WithBaseURL(server.URL),
-	)
+	}, options...)
+
+	client, err := NewDonatelyClient(options...)
 	require.NoError(t, err)
 
 	return server, client
@@ -182,6 +228,74 @@ func TestFindPerson(t *testing.T) {
 	assert.Equal(t, expectedPerson.ID, person.ID)
 }
 
+func TestSearchPeople(t *testing.T) {
+	server, client := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/people", r.URL.Path)
+
+		params := r.URL.Query()
+		assert.Equal(t, "acc_123", params.Get("account_id"))
+		assert.Equal(t, "Smith", params.Get("name"))
+		assert.Equal(t, "1704067200", params.Get("updated_after"))
+		assert.Empty(t, params.Get("updated_before"))
+		assert.Equal(t, "true", params.Get("has_donated"))
+		assert.Equal(t, "last_name", params.Get("sort_by"))
+		assert.Equal(t, "asc", params.Get("order"))
+		assert.Equal(t, "50", params.Get("limit"))
+
+		json.NewEncoder(w).Encode(APIResponse{
+			Data: mustMarshal(t, []Person{{ID: "person_1", LastName: "Smith"}}),
+		})
+	})
+	defer server.Close()
+
+	page, err := client.SearchPeople(context.Background(), Account{ID: "acc_123"}, PeopleQuery{
+		Name:       "Smith",
+		Updated:    TimeRange{After: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+		HasDonated: Bool(true),
+		SortBy:     "last_name",
+		Order:      SortAscending,
+		Limit:      50,
+	})
+	require.NoError(t, err)
+
+	require.Len(t, page.Items, 1)
+	assert.Equal(t, "person_1", page.Items[0].ID)
+	assert.False(t, page.HasMore)
+}
+
+func TestFindPersonByEmail(t *testing.T) {
+	server, client := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/people", r.URL.Path)
+
+		var people []Person
+		if r.URL.Query().Get("email") == "Jane@Example.com" {
+			people = []Person{
+				{ID: "person_1", Email: "jane.doe@example.com"},
+				{ID: "person_2", Email: "jane@example.com"},
+			}
+		}
+
+		json.NewEncoder(w).Encode(APIResponse{Data: mustMarshal(t, people)})
+	})
+	defer server.Close()
+
+	person, err := client.FindPersonByEmail(context.Background(), " Jane@Example.com ", Account{ID: "acc_123"})
+	require.NoError(t, err)
+	assert.Equal(t, "person_2", person.ID)
+
+	_, err = client.FindPersonByEmail(context.Background(), "nobody@example.com", Account{ID: "acc_123"})
+	require.ErrorIs(t, err, ErrNotFound)
+
+	var notFound *PersonNotFoundError
+	require.ErrorAs(t, err, &notFound)
+	assert.Equal(t, "nobody@example.com", notFound.Email)
+	assert.Equal(t, "acc_123", notFound.AccountID)
+
+	_, err = client.FindPersonByEmail(context.Background(), "", Account{ID: "acc_123"})
+	assert.ErrorIs(t, err, ErrValidation)
+}
+
 func TestMe(t *testing.T) {
 	expectedPerson := Person{
 		ID:    "person_me",
@@ -262,6 +376,7 @@ func TestSavePersonMissingAccount(t *testing.T) {
 	_, err := client.SavePerson(context.Background(), inputPerson)
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "missing account information")
+	assert.ErrorIs(t, err, ErrValidation)
 }
 
 func TestListDonations(t *testing.T) {
@@ -292,6 +407,81 @@ func TestListDonations(t *testing.T) {
 	assert.Len(t, donations, len(expectedDonations))
 }
 
+func TestSearchDonations(t *testing.T) {
+	account := Account{ID: "acc_123"}
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	server, client := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/donations", r.URL.Path)
+
+		params := r.URL.Query()
+		assert.Equal(t, "acc_123", params.Get("account_id"))
+		assert.Equal(t, "1704067200", params.Get("donation_date_after"))
+		assert.Equal(t, "1706745600", params.Get("donation_date_before"))
+		assert.Empty(t, params.Get("created_after"))
+		assert.Equal(t, "processed", params.Get("status"))
+		assert.Equal(t, "camp_1", params.Get("campaign_id"))
+		assert.Equal(t, "donor@example.com", params.Get("email"))
+		assert.Equal(t, "false", params.Get("recurring"))
+		assert.Empty(t, params.Get("livemode"))
+		assert.Equal(t, "500", params.Get("min_amount_in_cents"))
+		assert.Equal(t, "10000", params.Get("max_amount_in_cents"))
+		assert.Equal(t, "amount_in_cents", params.Get("sort_by"))
+		assert.Equal(t, "desc", params.Get("order"))
+		assert.Equal(t, "20", params.Get("offset"))
+		assert.Equal(t, "10", params.Get("limit"))
+
+		json.NewEncoder(w).Encode(APIResponse{
+			Data:    mustMarshal(t, []Donation{{ID: "don_1", AmountInCents: 1000}}),
+			Summary: &Summary{TotalCount: 21, Offset: 20, Limit: 10},
+		})
+	})
+	defer server.Close()
+
+	page, err := client.SearchDonations(context.Background(), account, DonationQuery{
+		DonationDate:     TimeRange{After: start, Before: end},
+		Status:           "processed",
+		CampaignID:       "camp_1",
+		Email:            "donor@example.com",
+		Recurring:        Bool(false),
+		MinAmountInCents: 500,
+		MaxAmountInCents: 10000,
+		SortBy:           "amount_in_cents",
+		Order:            SortDescending,
+		Offset:           20,
+		Limit:            10,
+	})
+	require.NoError(t, err)
+
+	require.Len(t, page.Items, 1)
+	assert.Equal(t, "don_1", page.Items[0].ID)
+	assert.Equal(t, 21, page.TotalCount)
+	assert.False(t, page.HasMore)
+}
+
+func TestSearchDonationsRejectsInvalidQueries(t *testing.T) {
+	server, client := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Error("no request should be made for an invalid query")
+	})
+	defer server.Close()
+
+	now := time.Now()
+
+	for name, query := range map[string]DonationQuery{
+		"inverted dates":   {DonationDate: TimeRange{After: now, Before: now.Add(-time.Hour)}},
+		"inverted created": {Created: TimeRange{After: now, Before: now}},
+		"negative amount":  {MinAmountInCents: -1},
+		"inverted amounts": {MinAmountInCents: 500, MaxAmountInCents: 100},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := client.SearchDonations(context.Background(), Account{ID: "acc_123"}, query)
+			assert.ErrorIs(t, err, ErrValidation)
+		})
+	}
+}
+
 func TestListMyDonations(t *testing.T) {
 	expectedDonations := []Donation{
 		{ID: "don_1", AmountInCents: 1000},
@@ -383,6 +573,40 @@ func TestSaveDonation(t *testing.T) {
 	assert.Equal(t, expectedDonation.ID, donation.ID)
 }
 
+func TestSaveDonationSendsDateCurrencyAndTrackingCodes(t *testing.T) {
+	server, client := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		params := r.URL.Query()
+		assert.Equal(t, "1704067200", params.Get("donation_date"))
+		assert.Equal(t, "USD", params.Get("currency"))
+		assert.Equal(t, "utm_source=newsletter", params.Get("tracking_codes"))
+
+		json.NewEncoder(w).Encode(APIResponse{Data: mustMarshal(t, Donation{ID: "don_new"})})
+	})
+	defer server.Close()
+
+	_, err := client.SaveDonation(context.Background(), Donation{
+		AmountInCents: 2500,
+		Account:       Account{ID: "acc_123"},
+		DonationDate:  1704067200,
+		Currency:      "USD",
+		TrackingCodes: "utm_source=newsletter",
+	})
+	require.NoError(t, err)
+
+	// Unset values aren't sent.
+	_, client = setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		params := r.URL.Query()
+		assert.False(t, params.Has("donation_date"))
+		assert.False(t, params.Has("currency"))
+		assert.False(t, params.Has("tracking_codes"))
+
+		json.NewEncoder(w).Encode(APIResponse{Data: mustMarshal(t, Donation{ID: "don_new"})})
+	})
+
+	_, err = client.SaveDonation(context.Background(), Donation{AmountInCents: 2500, Account: Account{ID: "acc_123"}})
+	require.NoError(t, err)
+}
+
 func TestRefundDonation(t *testing.T) {
 	inputDonation := Donation{
 		ID:      "don_123",
@@ -454,6 +678,52 @@ func TestListSubscriptions(t *testing.T) {
 	assert.Len(t, subscriptions, len(expectedSubscriptions))
 }
 
+func TestSearchSubscriptions(t *testing.T) {
+	server, client := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/subscriptions", r.URL.Path)
+
+		params := r.URL.Query()
+		assert.Equal(t, "acc_123", params.Get("account_id"))
+		assert.Equal(t, "active", params.Get("status"))
+		assert.Equal(t, "monthly", params.Get("recurring_frequency"))
+		assert.Equal(t, "camp_1", params.Get("campaign_id"))
+		assert.Equal(t, "person_1", params.Get("person_id"))
+		assert.Equal(t, "1704067200", params.Get("next_charge_date_after"))
+		assert.Equal(t, "1704672000", params.Get("next_charge_date_before"))
+		assert.Equal(t, "100", params.Get("offset"))
+		assert.Equal(t, "100", params.Get("limit"))
+
+		json.NewEncoder(w).Encode(APIResponse{
+			Data:    mustMarshal(t, []Subscription{{ID: "sub_1", Status: "active"}}),
+			Summary: &Summary{TotalCount: 350, Offset: 100, Limit: 100, HasMore: true},
+		})
+	})
+	defer server.Close()
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	page, err := client.SearchSubscriptions(context.Background(), Account{ID: "acc_123"}, SubscriptionQuery{
+		Status:             "active",
+		RecurringFrequency: "monthly",
+		CampaignID:         "camp_1",
+		PersonID:           "person_1",
+		NextCharge:         TimeRange{After: start, Before: start.AddDate(0, 0, 7)},
+		Offset:             100,
+		Limit:              100,
+	})
+	require.NoError(t, err)
+
+	require.Len(t, page.Items, 1)
+	assert.Equal(t, 350, page.TotalCount)
+	assert.True(t, page.HasMore)
+
+	_, err = client.SearchSubscriptions(context.Background(), Account{ID: "acc_123"}, SubscriptionQuery{
+		NextCharge: TimeRange{After: start, Before: start.AddDate(0, 0, -7)},
+	})
+	assert.ErrorIs(t, err, ErrValidation)
+}
+
 func TestListMySubscriptions(t *testing.T) {
 	expectedSubscriptions := []Subscription{
 		{ID: "sub_1", AmountInCents: 1000},
@@ -563,6 +833,55 @@ func TestListCampaigns(t *testing.T) {
 	assert.Len(t, campaigns, len(expectedCampaigns))
 }
 
+func TestSearchCampaigns(t *testing.T) {
+	server, client := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/campaigns", r.URL.Path)
+
+		params := r.URL.Query()
+		assert.Equal(t, "acc_123", params.Get("account_id"))
+		assert.Equal(t, "active", params.Get("status"))
+		assert.Equal(t, "peer_to_peer", params.Get("type"))
+		assert.Empty(t, params.Get("start_date_after"))
+		assert.Equal(t, "2024-06-01T00:00:00Z", params.Get("start_date_before"))
+		assert.Equal(t, "2024-06-01T00:00:00Z", params.Get("end_date_after"))
+		assert.Equal(t, "percent_funded", params.Get("sort_by"))
+		assert.Equal(t, "desc", params.Get("order"))
+		assert.Equal(t, "5", params.Get("limit"))
+
+		json.NewEncoder(w).Encode(APIResponse{
+			Data: mustMarshal(t, []Campaign{
+				{ID: "camp_1", PercentFunded: 90},
+				{ID: "camp_2", PercentFunded: 40},
+			}),
+			Summary: &Summary{TotalCount: 2, Limit: 5},
+		})
+	})
+	defer server.Close()
+
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	page, err := client.SearchCampaigns(context.Background(), Account{ID: "acc_123"}, CampaignQuery{
+		Status:    "active",
+		Type:      "peer_to_peer",
+		StartDate: TimeRange{Before: now},
+		EndDate:   TimeRange{After: now},
+		SortBy:    CampaignSortPercentFunded,
+		Order:     SortDescending,
+		Limit:     5,
+	})
+	require.NoError(t, err)
+
+	require.Len(t, page.Items, 2)
+	assert.Equal(t, "camp_1", page.Items[0].ID)
+	assert.False(t, page.HasMore)
+
+	_, err = client.SearchCampaigns(context.Background(), Account{ID: "acc_123"}, CampaignQuery{
+		EndDate: TimeRange{After: now, Before: now.AddDate(0, -1, 0)},
+	})
+	assert.ErrorIs(t, err, ErrValidation)
+}
+
 func TestFindCampaign(t *testing.T) {
 	expectedCampaign := Campaign{
 		ID:    "camp_123",
@@ -674,6 +993,78 @@ func TestHTTPErrorHandling(t *testing.T) {
 	assert.Contains(t, err.Error(), "404")
 }
 
+func TestAPIErrorClassification(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		sentinel   error
+	}{
+		{name: "not found", statusCode: http.StatusNotFound, sentinel: ErrNotFound},
+		{name: "unauthorized", statusCode: http.StatusUnauthorized, sentinel: ErrUnauthorized},
+		{name: "forbidden", statusCode: http.StatusForbidden, sentinel: ErrUnauthorized},
+		{name: "bad request", statusCode: http.StatusBadRequest, sentinel: ErrValidation},
+		{name: "unprocessable entity", statusCode: http.StatusUnprocessableEntity, sentinel: ErrValidation},
+		{name: "too many requests", statusCode: http.StatusTooManyRequests, sentinel: ErrRateLimited},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, client := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.statusCode)
+				json.NewEncoder(w).Encode(APIResponse{
+					Type:      "invalid_request_error",
+					Message:   "something went wrong",
+					Code:      "some_code",
+					RequestID: "req_123",
+				})
+			})
+			defer server.Close()
+
+			_, err := client.FindDonation(context.Background(), "don_123", Account{ID: "acc_123"})
+			require.Error(t, err)
+			assert.ErrorIs(t, err, tt.sentinel)
+
+			var apiErr *APIError
+			require.ErrorAs(t, err, &apiErr)
+			assert.Equal(t, tt.statusCode, apiErr.StatusCode)
+			assert.Equal(t, "some_code", apiErr.Code)
+			assert.Equal(t, "invalid_request_error", apiErr.Type)
+			assert.Equal(t, "something went wrong", apiErr.Message)
+			assert.Equal(t, "req_123", apiErr.RequestID)
+			assert.NotEmpty(t, apiErr.Body)
+		})
+	}
+}
+
+func TestAPIErrorWithNonJSONBody(t *testing.T) {
+	server, client := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte("Not Found"))
+	})
+	defer server.Close()
+
+	_, err := client.FindCampaign(context.Background(), "camp_123", Account{ID: "acc_123"})
+	require.Error(t, err)
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, []byte("Not Found"), apiErr.Body)
+}
+
+func TestSaveDonationPropagatesAPIError(t *testing.T) {
+	server, client := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		json.NewEncoder(w).Encode(APIResponse{})
+	})
+	defer server.Close()
+
+	_, err := client.SaveDonation(context.Background(), Donation{Account: Account{ID: "acc_123"}})
+	require.Error(t, err)
+	assert.ErrorIs(t, err, ErrValidation)
+}
+
 func TestRetryOnRetryableError(t *testing.T) {
 	attempts := 0
 	server, _ := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
@@ -712,6 +1103,277 @@ func TestRetryOnRetryableError(t *testing.T) {
 	assert.Equal(t, 2, attempts)
 }
 
+func TestRetryAppliesToEveryMethod(t *testing.T) {
+	attempts := 0
+	server, client := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		resp := APIResponse{
+			Data: mustMarshal(t, Account{ID: "acc_123"}),
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resp)
+	}, WithRetry(), WithBackOff(fastBackOff))
+	defer server.Close()
+
+	account, err := client.FindAccount(context.Background(), "acc_123")
+	require.NoError(t, err)
+
+	assert.Equal(t, "acc_123", account.ID)
+	assert.Equal(t, 3, attempts)
+}
+
+func TestRetryStopsAfterMaxAttempts(t *testing.T) {
+	attempts := 0
+	server, client := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusBadGateway)
+	}, WithRetry(), WithBackOff(fastBackOff), WithMaxAttempts(2))
+	defer server.Close()
+
+	_, err := client.ListCampaigns(context.Background(), Account{ID: "acc_123"})
+	require.Error(t, err)
+
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusBadGateway, apiErr.StatusCode)
+	assert.Equal(t, 2, attempts)
+}
+
+func TestRetrySkipsClientErrors(t *testing.T) {
+	attempts := 0
+	server, client := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusNotFound)
+	}, WithRetry(), WithBackOff(fastBackOff))
+	defer server.Close()
+
+	_, err := client.FindPerson(context.Background(), "person_123", Account{ID: "acc_123"})
+	require.ErrorIs(t, err, ErrNotFound)
+	assert.Equal(t, 1, attempts)
+}
+
+func TestRetrySkipsNonIdempotentServerErrors(t *testing.T) {
+	attempts := 0
+	server, client := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusInternalServerError)
+	}, WithRetry(), WithBackOff(fastBackOff), WithIdempotencyKeyGenerator(func() string { return "" }))
+	defer server.Close()
+
+	_, err := client.SaveCampaign(context.Background(), Campaign{Title: "New Campaign"})
+	require.Error(t, err)
+	assert.Equal(t, 1, attempts)
+}
+
+func TestRetriedCreateReusesIdempotencyKey(t *testing.T) {
+	var keys []string
+	server, client := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		keys = append(keys, r.Header.Get("Idempotency-Key"))
+		if len(keys) < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+
+		resp := APIResponse{
+			Data: mustMarshal(t, Donation{ID: "don_new"}),
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resp)
+	}, WithRetry(), WithBackOff(fastBackOff))
+	defer server.Close()
+
+	donation, err := client.SaveDonation(context.Background(), Donation{Account: Account{ID: "acc_123"}, AmountInCents: 1000})
+	require.NoError(t, err)
+
+	assert.Equal(t, "don_new", donation.ID)
+	require.Len(t, keys, 3)
+	assert.NotEmpty(t, keys[0])
+	assert.Equal(t, keys[0], keys[1])
+	assert.Equal(t, keys[0], keys[2])
+}
+
+func TestIdempotencyKeys(t *testing.T) {
+	t.Run("each logical call gets its own key", func(t *testing.T) {
+		var keys []string
+		server, client := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+			keys = append(keys, r.Header.Get("Idempotency-Key"))
+			json.NewEncoder(w).Encode(APIResponse{Data: mustMarshal(t, Campaign{ID: "camp_new"})})
+		})
+		defer server.Close()
+
+		_, err := client.SaveCampaign(context.Background(), Campaign{Title: "First"})
+		require.NoError(t, err)
+		_, err = client.SaveCampaign(context.Background(), Campaign{Title: "Second"})
+		require.NoError(t, err)
+
+		require.Len(t, keys, 2)
+		assert.NotEmpty(t, keys[0])
+		assert.NotEqual(t, keys[0], keys[1])
+	})
+
+	t.Run("key supplied via context", func(t *testing.T) {
+		var keys []string
+		server, client := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+			keys = append(keys, r.Header.Get("Idempotency-Key"))
+			json.NewEncoder(w).Encode(APIResponse{Data: mustMarshal(t, Person{ID: "person_new"})})
+		})
+		defer server.Close()
+
+		ctx := WithIdempotencyKey(context.Background(), "my-key")
+		_, err := client.SavePerson(ctx, Person{Accounts: []Account{{ID: "acc_123"}}})
+		require.NoError(t, err)
+
+		// A re-run of the same call with the same key sends the same key again.
+		_, err = client.SavePerson(WithIdempotencyKey(context.Background(), "my-key"), Person{Accounts: []Account{{ID: "acc_123"}}})
+		require.NoError(t, err)
+
+		require.Len(t, keys, 2)
+		assert.NotEmpty(t, keys[0])
+		assert.Equal(t, keys[0], keys[1])
+	})
+
+	t.Run("key supplied via context is scoped to each call", func(t *testing.T) {
+		var keys []string
+		server, client := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+			keys = append(keys, r.Header.Get("Idempotency-Key"))
+			json.NewEncoder(w).Encode(APIResponse{Data: mustMarshal(t, Person{ID: "person_new"})})
+		})
+		defer server.Close()
+
+		ctx := WithIdempotencyKey(context.Background(), "my-key")
+		_, err := client.SavePerson(ctx, Person{Email: "first@example.com", Accounts: []Account{{ID: "acc_123"}}})
+		require.NoError(t, err)
+		_, err = client.SavePerson(ctx, Person{Email: "second@example.com", Accounts: []Account{{ID: "acc_123"}}})
+		require.NoError(t, err)
+
+		require.Len(t, keys, 2)
+		assert.NotEqual(t, keys[0], keys[1])
+	})
+
+	t.Run("key supplied via generator", func(t *testing.T) {
+		server, client := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "generated-key", r.Header.Get("Idempotency-Key"))
+			json.NewEncoder(w).Encode(APIResponse{Data: json.RawMessage(`{}`)})
+		}, WithIdempotencyKeyGenerator(func() string { return "generated-key" }))
+		defer server.Close()
+
+		err := client.DeleteCampaign(context.Background(), Campaign{ID: "camp_123"})
+		require.NoError(t, err)
+	})
+
+	t.Run("no key for reads", func(t *testing.T) {
+		server, client := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+			assert.Empty(t, r.Header.Get("Idempotency-Key"))
+			json.NewEncoder(w).Encode(APIResponse{Data: mustMarshal(t, Account{ID: "acc_123"})})
+		})
+		defer server.Close()
+
+		_, err := client.FindAccount(context.Background(), "acc_123")
+		require.NoError(t, err)
+	})
+}
+
+func TestRetryRetriesThrottledRequests(t *testing.T) {
+	attempts := 0
+	server, client := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+
+		resp := APIResponse{
+			Data: mustMarshal(t, Subscription{ID: "sub_new"}),
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resp)
+	}, WithRetry(), WithBackOff(fastBackOff))
+	defer server.Close()
+
+	subscription, err := client.SaveSubscription(context.Background(), Subscription{AmountInCents: 1500})
+	require.NoError(t, err)
+
+	assert.Equal(t, "sub_new", subscription.ID)
+	assert.Equal(t, 2, attempts)
+}
+
+func TestNoRetryByDefault(t *testing.T) {
+	attempts := 0
+	server, client := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	defer server.Close()
+
+	_, err := client.FindAccount(context.Background(), "acc_123")
+	require.Error(t, err)
+	assert.Equal(t, 1, attempts)
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+func TestWithTransport(t *testing.T) {
+	calls := 0
+	transport := roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		calls++
+		return http.DefaultTransport.RoundTrip(r)
+	})
+
+	server, client := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(APIResponse{Data: mustMarshal(t, Account{ID: "acc_123"})})
+	}, WithTransport(transport))
+	defer server.Close()
+
+	_, err := client.FindAccount(context.Background(), "acc_123")
+	require.NoError(t, err)
+	assert.Equal(t, 1, calls)
+}
+
+func TestWithHTTPClientIsNotModified(t *testing.T) {
+	httpClient := &http.Client{}
+
+	server, client := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(APIResponse{Data: mustMarshal(t, Account{ID: "acc_123"})})
+	}, WithHTTPClient(httpClient), WithTimeout(time.Second), WithTransport(http.DefaultTransport))
+	defer server.Close()
+
+	_, err := client.FindAccount(context.Background(), "acc_123")
+	require.NoError(t, err)
+	assert.Zero(t, httpClient.Timeout)
+	assert.Nil(t, httpClient.Transport)
+}
+
+func TestTimeoutIsRetried(t *testing.T) {
+	var attempts atomic.Int32
+	server, client := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) == 1 {
+			time.Sleep(200 * time.Millisecond)
+		}
+
+		json.NewEncoder(w).Encode(APIResponse{Data: mustMarshal(t, Account{ID: "acc_123"})})
+	}, WithTimeout(50*time.Millisecond), WithRetry(), WithBackOff(fastBackOff))
+	defer server.Close()
+
+	account, err := client.FindAccount(context.Background(), "acc_123")
+	require.NoError(t, err)
+
+	assert.Equal(t, "acc_123", account.ID)
+	assert.Equal(t, int32(2), attempts.Load())
+}
+
+func fastBackOff() backoff.BackOff {
+	return backoff.NewConstantBackOff(time.Millisecond)
+}
+
 func mustMarshal(t *testing.T, v any) json.RawMessage {
 	data, err := json.Marshal(v)
 	require.NoError(t, err)
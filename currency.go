@@ -0,0 +1,21 @@
+package donately
+
+import "strings"
+
+// minorUnits holds the ISO 4217 currencies whose minor unit isn't a hundredth.
+var minorUnits = map[string]int{
+	"BIF": 0, "CLP": 0, "DJF": 0, "GNF": 0, "ISK": 0, "JPY": 0, "KMF": 0, "KRW": 0,
+	"PYG": 0, "RWF": 0, "UGX": 0, "UYI": 0, "VND": 0, "VUV": 0, "XAF": 0, "XOF": 0, "XPF": 0,
+	"BHD": 3, "IQD": 3, "JOD": 3, "KWD": 3, "LYD": 3, "OMR": 3, "TND": 3,
+}
+
+// MinorUnits returns the number of decimal places of currency's minor unit, the unit amounts such
+// as Donation.AmountInCents are expressed in: 0 for JPY, 3 for KWD and 2 for most others.
+// The currency code is case-insensitive, and unknown or empty codes are assumed to have 2.
+func MinorUnits(currency string) int {
+	if digits, ok := minorUnits[strings.ToUpper(currency)]; ok {
+		return digits
+	}
+
+	return 2
+}
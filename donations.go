@@ -1,6 +1,9 @@
 package donately
 
 import (
+	"fmt"
+	"net/url"
+	"strconv"
 	"time"
 )
 
@@ -82,3 +85,102 @@ type ChargeSource struct {
 	Name               string         `json:"name"`
 	TokenizationMethod *string        `json:"tokenization_method"`
 }
+
+// DonationQuery filters, sorts and pages the donations returned by SearchDonations.
+// Zero-valued fields are ignored.
+type DonationQuery struct {
+	// DonationDate restricts results to donations made within the range.
+	DonationDate TimeRange
+
+	// Created restricts results to donations recorded within the range.
+	Created TimeRange
+
+	// Updated restricts results to donations last updated within the range.
+	Updated TimeRange
+
+	// Status restricts results to donations with the given status (e.g. "processed").
+	Status string
+
+	// CampaignID restricts results to donations made to the given campaign.
+	CampaignID string
+
+	// PersonID restricts results to donations made by the given person.
+	PersonID string
+
+	// Email restricts results to donations made by the person with the given email address.
+	Email string
+
+	// Recurring, when set, restricts results to recurring (true) or one-time (false) donations.
+	Recurring *bool
+
+	// Livemode, when set, restricts results to live (true) or test (false) donations.
+	Livemode *bool
+
+	// MinAmountInCents excludes donations smaller than this amount.
+	MinAmountInCents int64
+
+	// MaxAmountInCents excludes donations larger than this amount.
+	MaxAmountInCents int64
+
+	// SortBy is the donation field to sort by, such as "donation_date" or "amount_in_cents".
+	SortBy string
+
+	// Order is the direction results are sorted in. If not provided, the API's default order is used.
+	Order SortOrder
+
+	// Offset is the number of matching donations to skip.
+	Offset int
+
+	// Limit is the maximum number of donations to return.
+	Limit int
+}
+
+func (q DonationQuery) validate() error {
+	if err := q.DonationDate.validate("donation date"); err != nil {
+		return err
+	}
+
+	if err := q.Created.validate("created"); err != nil {
+		return err
+	}
+
+	if err := q.Updated.validate("updated"); err != nil {
+		return err
+	}
+
+	if q.MinAmountInCents < 0 || q.MaxAmountInCents < 0 {
+		return fmt.Errorf("%w: amount range must not be negative", ErrValidation)
+	}
+
+	if q.MaxAmountInCents > 0 && q.MinAmountInCents > q.MaxAmountInCents {
+		return fmt.Errorf("%w: amount range is empty", ErrValidation)
+	}
+
+	return nil
+}
+
+func (q DonationQuery) values() url.Values {
+	params := url.Values{}
+
+	q.DonationDate.set(params, "donation_date")
+	q.Created.set(params, "created")
+	q.Updated.set(params, "updated")
+	setString(params, "status", q.Status)
+	setString(params, "campaign_id", q.CampaignID)
+	setString(params, "person_id", q.PersonID)
+	setString(params, "email", q.Email)
+	setBool(params, "recurring", q.Recurring)
+	setBool(params, "livemode", q.Livemode)
+
+	if q.MinAmountInCents > 0 {
+		params.Set("min_amount_in_cents", strconv.FormatInt(q.MinAmountInCents, 10))
+	}
+
+	if q.MaxAmountInCents > 0 {
+		params.Set("max_amount_in_cents", strconv.FormatInt(q.MaxAmountInCents, 10))
+	}
+
+	setSort(params, q.SortBy, q.Order)
+
+	return params
+}
@@ -0,0 +1,97 @@
+package donately
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Sentinel errors that classify failures returned by the Donately API.
+// Every error produced by a Client method can be tested against them with errors.Is.
+var (
+	// ErrNotFound indicates the requested record does not exist.
+	ErrNotFound = errors.New("donately: not found")
+
+	// ErrUnauthorized indicates the API key is missing, invalid or lacks permission.
+	ErrUnauthorized = errors.New("donately: unauthorized")
+
+	// ErrValidation indicates the API rejected the request parameters.
+	ErrValidation = errors.New("donately: validation failed")
+
+	// ErrRateLimited indicates the API throttled the request.
+	ErrRateLimited = errors.New("donately: rate limited")
+)
+
+// APIError describes a failed call to the Donately API.
+// It carries the HTTP status along with the error details reported in the response body,
+// and can be extracted from any Client error with errors.As.
+type APIError struct {
+	StatusCode int
+	Code       string
+	Type       string
+	Message    string
+	RequestID  string
+	Body       []byte
+
+	// RetryAfter is the delay the API asked for before the request is retried, if any.
+	RetryAfter time.Duration
+}
+
+func newAPIError(resp *Response, apiResp APIResponse) *APIError {
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode,
+		Code:       apiResp.Code,
+		Type:       apiResp.Type,
+		Message:    apiResp.Message,
+		RequestID:  apiResp.RequestID,
+		Body:       resp.Body,
+	}
+
+	if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable {
+		apiErr.RetryAfter = retryAfter(resp.Header)
+	}
+
+	return apiErr
+}
+
+func (e *APIError) Error() string {
+	if e.Code != "" || e.Message != "" {
+		return fmt.Sprintf("API error: %s - (%s) %s (HTTP %d)", e.Code, e.Type, e.Message, e.StatusCode)
+	}
+
+	return fmt.Sprintf("HTTP error: %d (Raw Response: %s)", e.StatusCode, e.Body)
+}
+
+// Is reports whether the error matches one of the package's sentinel errors,
+// based on the HTTP status code of the response.
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized || e.StatusCode == http.StatusForbidden
+	case ErrValidation:
+		return e.StatusCode == http.StatusBadRequest || e.StatusCode == http.StatusUnprocessableEntity
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	}
+
+	return false
+}
+
+// PersonNotFoundError is returned by FindPersonByEmail when no person on the account has the email address.
+// It matches ErrNotFound with errors.Is.
+type PersonNotFoundError struct {
+	Email     string
+	AccountID string
+}
+
+func (e *PersonNotFoundError) Error() string {
+	return fmt.Sprintf("donately: no person with email %q on account %s", e.Email, e.AccountID)
+}
+
+// Is reports whether target is ErrNotFound.
+func (e *PersonNotFoundError) Is(target error) bool {
+	return target == ErrNotFound
+}
@@ -0,0 +1,229 @@
+package donately
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"strings"
+	"sync"
+)
+
+// EventType identifies what happened in an Event.
+type EventType string
+
+// Event types sent by Donately.
+const (
+	EventDonationCreated  EventType = "donation.created"
+	EventDonationUpdated  EventType = "donation.updated"
+	EventDonationRefunded EventType = "donation.refunded"
+	EventDonationFailed   EventType = "donation.failed"
+
+	EventSubscriptionCreated   EventType = "subscription.created"
+	EventSubscriptionUpdated   EventType = "subscription.updated"
+	EventSubscriptionCancelled EventType = "subscription.cancelled"
+
+	EventPersonCreated EventType = "person.created"
+	EventPersonUpdated EventType = "person.updated"
+
+	EventCampaignCreated EventType = "campaign.created"
+	EventCampaignUpdated EventType = "campaign.updated"
+)
+
+// Resource returns the kind of record the event is about, e.g. "donation" for EventDonationCreated.
+func (t EventType) Resource() string {
+	resource, _, _ := strings.Cut(string(t), ".")
+	return resource
+}
+
+// Event is a notification that something happened to a record in Donately.
+type Event struct {
+	ID        string          `json:"id"`
+	Type      EventType       `json:"type"`
+	Created   int64           `json:"created"`
+	Livemode  bool            `json:"livemode"`
+	AccountID string          `json:"account_id"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// Donation decodes the event's data as a Donation.
+func (e Event) Donation() (Donation, error) {
+	return decodeEventData[Donation](e, "donation")
+}
+
+// Subscription decodes the event's data as a Subscription.
+func (e Event) Subscription() (Subscription, error) {
+	return decodeEventData[Subscription](e, "subscription")
+}
+
+// Person decodes the event's data as a Person.
+func (e Event) Person() (Person, error) {
+	return decodeEventData[Person](e, "person")
+}
+
+// Campaign decodes the event's data as a Campaign.
+func (e Event) Campaign() (Campaign, error) {
+	return decodeEventData[Campaign](e, "campaign")
+}
+
+func decodeEventData[T any](e Event, kind string) (T, error) {
+	var v T
+	if err := json.Unmarshal(e.Data, &v); err != nil {
+		return v, fmt.Errorf("failed to unmarshal %s event data: %w", kind, err)
+	}
+
+	return v, nil
+}
+
+// EventHandler handles an Event.
+type EventHandler func(context.Context, Event) error
+
+// HandlerError reports the failure of a single handler during Dispatch.
+type HandlerError struct {
+	// EventID and EventType identify the event being handled.
+	EventID   string
+	EventType EventType
+
+	// Handler is the position of the failed handler among those the event was dispatched to.
+	Handler int
+
+	// Err is the error returned by the handler, or a *PanicError if it panicked.
+	Err error
+}
+
+func (e *HandlerError) Error() string {
+	return fmt.Sprintf("handler %d for %s event %s: %v", e.Handler, e.EventType, e.EventID, e.Err)
+}
+
+func (e *HandlerError) Unwrap() error {
+	return e.Err
+}
+
+// PanicError is the error reported for a handler that panicked.
+type PanicError struct {
+	// Value is the value passed to panic.
+	Value any
+
+	// Stack is the stack trace of the goroutine at the time of the panic.
+	Stack []byte
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic: %v", e.Value)
+}
+
+// DispatchError is returned by Dispatch when one or more handlers fail.
+type DispatchError struct {
+	Errors []*HandlerError
+}
+
+func (e *DispatchError) Error() string {
+	if len(e.Errors) == 1 {
+		return e.Errors[0].Error()
+	}
+
+	return fmt.Sprintf("%d handlers failed; first: %v", len(e.Errors), e.Errors[0])
+}
+
+// Unwrap returns the errors of the failed handlers, so errors.Is and errors.As see through to them.
+func (e *DispatchError) Unwrap() []error {
+	errs := make([]error, len(e.Errors))
+	for i, err := range e.Errors {
+		errs[i] = err
+	}
+
+	return errs
+}
+
+// Dispatcher routes events to the handlers registered for their type. Handlers run one after the
+// other in registration order; a failing or panicking handler doesn't prevent the others from running.
+// A Dispatcher is safe for concurrent use.
+type Dispatcher struct {
+	mu       sync.RWMutex
+	handlers map[EventType][]EventHandler
+	any      []EventHandler
+}
+
+// NewDispatcher returns a Dispatcher with no handlers.
+func NewDispatcher() *Dispatcher {
+	return &Dispatcher{handlers: map[EventType][]EventHandler{}}
+}
+
+// On registers handlers for events of the given type. Handlers registered earlier run first.
+func (d *Dispatcher) On(eventType EventType, handlers ...EventHandler) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.handlers[eventType] = append(d.handlers[eventType], handlers...)
+}
+
+// OnAny registers handlers for events of every type. They run after the handlers registered for
+// the event's type.
+func (d *Dispatcher) OnAny(handlers ...EventHandler) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.any = append(d.any, handlers...)
+}
+
+// Dispatch passes event to every handler registered for its type, then to those registered with OnAny.
+// If any handler fails or panics, the returned error is a *DispatchError describing each failure.
+func (d *Dispatcher) Dispatch(ctx context.Context, event Event) error {
+	d.mu.RLock()
+	handlers := make([]EventHandler, 0, len(d.handlers[event.Type])+len(d.any))
+	handlers = append(handlers, d.handlers[event.Type]...)
+	handlers = append(handlers, d.any...)
+	d.mu.RUnlock()
+
+	var failures []*HandlerError
+	for i, handler := range handlers {
+		if err := safelyHandle(ctx, handler, event); err != nil {
+			failures = append(failures, &HandlerError{EventID: event.ID, EventType: event.Type, Handler: i, Err: err})
+		}
+	}
+
+	if len(failures) > 0 {
+		return &DispatchError{Errors: failures}
+	}
+
+	return nil
+}
+
+// Replay dispatches the events in store selected by filter again, oldest first, for example to
+// re-process events once a bug in a handler has been fixed. Every selected event is dispatched even if
+// some fail; the number that succeeded is returned along with an error joining each failure.
+func (d *Dispatcher) Replay(ctx context.Context, store EventStore, filter EventFilter) (int, error) {
+	events, err := store.Events(ctx, filter)
+	if err != nil {
+		return 0, fmt.Errorf("failed to load events: %w", err)
+	}
+
+	replayed := 0
+	var errs []error
+
+	for _, event := range events {
+		if err := ctx.Err(); err != nil {
+			return replayed, errors.Join(append(errs, err)...)
+		}
+
+		if err := d.Dispatch(ctx, event); err != nil {
+			errs = append(errs, fmt.Errorf("failed to replay event %s: %w", event.ID, err))
+			continue
+		}
+
+		replayed++
+	}
+
+	return replayed, errors.Join(errs...)
+}
+
+func safelyHandle(ctx context.Context, handler EventHandler, event Event) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = &PanicError{Value: r, Stack: debug.Stack()}
+		}
+	}()
+
+	return handler(ctx, event)
+}
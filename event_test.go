@@ -0,0 +1,104 @@
+package donately
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventData(t *testing.T) {
+	event := Event{Type: EventPersonUpdated, Data: []byte(`{"id":"person_1","email":"jane@example.com"}`)}
+
+	person, err := event.Person()
+	require.NoError(t, err)
+	assert.Equal(t, "jane@example.com", person.Email)
+
+	campaign, err := Event{Data: []byte(`{"id":"camp_1","title":"Spring"}`)}.Campaign()
+	require.NoError(t, err)
+	assert.Equal(t, "Spring", campaign.Title)
+
+	_, err = Event{Data: []byte(`"nope"`)}.Subscription()
+	assert.ErrorContains(t, err, "failed to unmarshal subscription event data")
+}
+
+func TestEventTypeResource(t *testing.T) {
+	assert.Equal(t, "donation", EventDonationRefunded.Resource())
+	assert.Equal(t, "subscription", EventSubscriptionCancelled.Resource())
+	assert.Equal(t, "custom", EventType("custom").Resource())
+}
+
+func TestDispatcher(t *testing.T) {
+	dispatcher := NewDispatcher()
+
+	var calls []string
+	record := func(name string) EventHandler {
+		return func(ctx context.Context, event Event) error {
+			calls = append(calls, name+":"+event.ID)
+			return nil
+		}
+	}
+
+	dispatcher.On(EventDonationCreated, record("first"), record("second"))
+	dispatcher.On(EventDonationCreated, record("third"))
+	dispatcher.On(EventPersonUpdated, record("person"))
+	dispatcher.OnAny(record("any"))
+
+	require.NoError(t, dispatcher.Dispatch(context.Background(), Event{ID: "evt_1", Type: EventDonationCreated}))
+	assert.Equal(t, []string{"first:evt_1", "second:evt_1", "third:evt_1", "any:evt_1"}, calls)
+
+	calls = nil
+	require.NoError(t, dispatcher.Dispatch(context.Background(), Event{ID: "evt_2", Type: EventCampaignUpdated}))
+	assert.Equal(t, []string{"any:evt_2"}, calls)
+
+	assert.NoError(t, NewDispatcher().Dispatch(context.Background(), Event{ID: "evt_3", Type: EventDonationCreated}))
+}
+
+func TestDispatcherReportsEachFailure(t *testing.T) {
+	errUnavailable := errors.New("database unavailable")
+
+	var ran []int
+	dispatcher := NewDispatcher()
+	dispatcher.On(EventSubscriptionCancelled,
+		func(ctx context.Context, event Event) error {
+			ran = append(ran, 0)
+			return errUnavailable
+		},
+		func(ctx context.Context, event Event) error {
+			ran = append(ran, 1)
+			return nil
+		},
+		func(ctx context.Context, event Event) error {
+			ran = append(ran, 2)
+			panic("boom")
+		},
+		func(ctx context.Context, event Event) error {
+			ran = append(ran, 3)
+			return nil
+		},
+	)
+
+	err := dispatcher.Dispatch(context.Background(), Event{ID: "evt_1", Type: EventSubscriptionCancelled})
+
+	assert.Equal(t, []int{0, 1, 2, 3}, ran, "every handler runs despite earlier failures")
+
+	var dispatchErr *DispatchError
+	require.ErrorAs(t, err, &dispatchErr)
+	require.Len(t, dispatchErr.Errors, 2)
+
+	assert.Equal(t, 0, dispatchErr.Errors[0].Handler)
+	assert.Equal(t, "evt_1", dispatchErr.Errors[0].EventID)
+	assert.Equal(t, EventSubscriptionCancelled, dispatchErr.Errors[0].EventType)
+	assert.ErrorIs(t, dispatchErr.Errors[0], errUnavailable)
+
+	assert.Equal(t, 2, dispatchErr.Errors[1].Handler)
+	var panicErr *PanicError
+	require.ErrorAs(t, dispatchErr.Errors[1], &panicErr)
+	assert.Equal(t, "boom", panicErr.Value)
+	assert.NotEmpty(t, panicErr.Stack)
+
+	assert.ErrorIs(t, err, errUnavailable)
+	assert.ErrorContains(t, err, "2 handlers failed")
+}
@@ -0,0 +1,297 @@
+package donately
+
+import (
+	"cmp"
+	"container/list"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"slices"
+	"sync"
+	"time"
+)
+
+// EventFilter selects stored events. A zero EventFilter selects every event.
+type EventFilter struct {
+	// IDs, when set, restricts the selection to events with these IDs.
+	IDs []string
+
+	// Types, when set, restricts the selection to events of these types.
+	Types []EventType
+
+	// Created restricts the selection to events created within the range.
+	Created TimeRange
+}
+
+func (f EventFilter) matches(event Event) bool {
+	if len(f.IDs) > 0 && !slices.Contains(f.IDs, event.ID) {
+		return false
+	}
+
+	if len(f.Types) > 0 && !slices.Contains(f.Types, event.Type) {
+		return false
+	}
+
+	return f.Created.IsZero() || f.Created.contains(time.Unix(event.Created, 0))
+}
+
+// EventStore records the webhook events that have been processed, so that events delivered more
+// than once are only handled once, and so that they can be replayed later with Dispatcher.Replay.
+// The methods map onto single statements of a SQL table keyed by event ID with a released flag (an
+// insert that only updates released rows on conflict, an update and a select).
+// Implementations must be safe for concurrent use.
+type EventStore interface {
+	// Claim records event unless an event with the same ID is already recorded and hasn't been
+	// released. It reports whether event was claimed, that is, whether it should be handled.
+	Claim(ctx context.Context, event Event) (bool, error)
+
+	// Release marks the event with the given ID, if any, as unhandled, so that it is claimed again
+	// when delivered again. The event stays recorded, so it can still be replayed.
+	Release(ctx context.Context, id string) error
+
+	// Events returns the recorded events selected by filter, oldest first.
+	Events(ctx context.Context, filter EventFilter) ([]Event, error)
+}
+
+// storedEvent is an event recorded by an EventStore along with when it was last delivered, and
+// whether its handling failed.
+type storedEvent struct {
+	Event    Event     `json:"event"`
+	SeenAt   time.Time `json:"seen_at"`
+	Released bool      `json:"released,omitempty"`
+}
+
+func selectEvents(stored []storedEvent, filter EventFilter) []Event {
+	var events []Event
+	for _, s := range stored {
+		if filter.matches(s.Event) {
+			events = append(events, s.Event)
+		}
+	}
+
+	slices.SortStableFunc(events, func(a, b Event) int {
+		return cmp.Compare(a.Created, b.Created)
+	})
+
+	return events
+}
+
+// MemoryEventStore is an EventStore that keeps events in memory, so they only survive for the
+// life of the process. It holds at most capacity events, forgetting the least recently delivered
+// ones first, and forgets events that haven't been delivered for longer than the TTL.
+type MemoryEventStore struct {
+	capacity int
+	ttl      time.Duration
+	now      func() time.Time
+
+	mu      sync.Mutex
+	order   *list.List // of *storedEvent, most recently delivered first
+	entries map[string]*list.Element
+}
+
+// NewMemoryEventStore returns an empty MemoryEventStore. A capacity or ttl of 0 means no limit.
+func NewMemoryEventStore(capacity int, ttl time.Duration) *MemoryEventStore {
+	return &MemoryEventStore{
+		capacity: capacity,
+		ttl:      ttl,
+		now:      time.Now,
+		order:    list.New(),
+		entries:  map[string]*list.Element{},
+	}
+}
+
+func (s *MemoryEventStore) Claim(_ context.Context, event Event) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.expire(now)
+
+	// A redelivered event is kept for another TTL, as the sender may still be retrying it.
+	if element, ok := s.entries[event.ID]; ok {
+		stored := element.Value.(*storedEvent)
+		claimed := stored.Released
+
+		stored.SeenAt = now
+		stored.Released = false
+		s.order.MoveToFront(element)
+
+		return claimed, nil
+	}
+
+	event.Data = slices.Clone(event.Data)
+	s.entries[event.ID] = s.order.PushFront(&storedEvent{Event: event, SeenAt: now})
+
+	if s.capacity > 0 && s.order.Len() > s.capacity {
+		s.remove(s.order.Back())
+	}
+
+	return true, nil
+}
+
+func (s *MemoryEventStore) Release(_ context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if element, ok := s.entries[id]; ok {
+		element.Value.(*storedEvent).Released = true
+	}
+
+	return nil
+}
+
+func (s *MemoryEventStore) Events(_ context.Context, filter EventFilter) ([]Event, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.expire(s.now())
+
+	stored := make([]storedEvent, 0, s.order.Len())
+	for element := s.order.Back(); element != nil; element = element.Prev() {
+		stored = append(stored, *element.Value.(*storedEvent))
+	}
+
+	return selectEvents(stored, filter), nil
+}
+
+// expire removes the events last delivered more than the TTL before now.
+func (s *MemoryEventStore) expire(now time.Time) {
+	if s.ttl <= 0 {
+		return
+	}
+
+	for element := s.order.Back(); element != nil && now.Sub(element.Value.(*storedEvent).SeenAt) > s.ttl; element = s.order.Back() {
+		s.remove(element)
+	}
+}
+
+func (s *MemoryEventStore) remove(element *list.Element) {
+	s.order.Remove(element)
+	delete(s.entries, element.Value.(*storedEvent).Event.ID)
+}
+
+// FileEventStore is an EventStore that keeps events in a single JSON file, so that they survive
+// restarts and can be replayed after a fix is deployed. Events that haven't been delivered for
+// longer than the TTL are dropped whenever the file is written. The whole file is rewritten on
+// each change, which suits the volume of webhooks a single account receives; busier deployments
+// should implement EventStore on a database.
+type FileEventStore struct {
+	path string
+	ttl  time.Duration
+	now  func() time.Time
+	mu   sync.Mutex
+}
+
+// NewFileEventStore returns a FileEventStore backed by the file at path. The file is created on the
+// first Claim if it doesn't exist. A ttl of 0 keeps events forever.
+func NewFileEventStore(path string, ttl time.Duration) *FileEventStore {
+	return &FileEventStore{path: path, ttl: ttl, now: time.Now}
+}
+
+func (s *FileEventStore) Claim(_ context.Context, event Event) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	events, err := s.read()
+	if err != nil {
+		return false, err
+	}
+
+	stored, seen := events[event.ID]
+	if !seen {
+		stored.Event = event
+	}
+
+	claimed := !seen || stored.Released
+
+	stored.SeenAt = s.now()
+	stored.Released = false
+	events[event.ID] = stored
+
+	if err := s.write(events); err != nil {
+		return false, err
+	}
+
+	return claimed, nil
+}
+
+func (s *FileEventStore) Release(_ context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	events, err := s.read()
+	if err != nil {
+		return err
+	}
+
+	stored, ok := events[id]
+	if !ok || stored.Released {
+		return nil
+	}
+
+	stored.Released = true
+	events[id] = stored
+
+	return s.write(events)
+}
+
+func (s *FileEventStore) Events(_ context.Context, filter EventFilter) ([]Event, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	events, err := s.read()
+	if err != nil {
+		return nil, err
+	}
+
+	stored := make([]storedEvent, 0, len(events))
+	for _, event := range events {
+		stored = append(stored, event)
+	}
+
+	slices.SortFunc(stored, func(a, b storedEvent) int {
+		return a.SeenAt.Compare(b.SeenAt)
+	})
+
+	return selectEvents(stored, filter), nil
+}
+
+// read returns the events in the file, leaving out those that have expired.
+func (s *FileEventStore) read() (map[string]storedEvent, error) {
+	events := map[string]storedEvent{}
+
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return events, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read events: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &events); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal events: %w", err)
+	}
+
+	if s.ttl > 0 {
+		now := s.now()
+		for id, event := range events {
+			if now.Sub(event.SeenAt) > s.ttl {
+				delete(events, id)
+			}
+		}
+	}
+
+	return events, nil
+}
+
+func (s *FileEventStore) write(events map[string]storedEvent) error {
+	data, err := json.MarshalIndent(events, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal events: %w", err)
+	}
+
+	return replaceFile(s.path, data, "event")
+}
@@ -0,0 +1,182 @@
+package donately
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func eventIDs(events []Event) []string {
+	ids := []string{}
+	for _, event := range events {
+		ids = append(ids, event.ID)
+	}
+
+	return ids
+}
+
+func testEventStore(t *testing.T, store EventStore) {
+	ctx := context.Background()
+
+	claimed, err := store.Claim(ctx, Event{ID: "evt_2", Type: EventDonationCreated, Created: 200, Data: []byte(`{"id":"don_1"}`)})
+	require.NoError(t, err)
+	assert.True(t, claimed)
+
+	claimed, err = store.Claim(ctx, Event{ID: "evt_2", Type: EventDonationCreated, Created: 200})
+	require.NoError(t, err)
+	assert.False(t, claimed, "redelivered events are not claimed again")
+
+	for _, event := range []Event{
+		{ID: "evt_1", Type: EventPersonUpdated, Created: 100},
+		{ID: "evt_3", Type: EventDonationRefunded, Created: 300},
+	} {
+		claimed, err := store.Claim(ctx, event)
+		require.NoError(t, err)
+		assert.True(t, claimed)
+	}
+
+	events, err := store.Events(ctx, EventFilter{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"evt_1", "evt_2", "evt_3"}, eventIDs(events))
+	assert.JSONEq(t, `{"id":"don_1"}`, string(events[1].Data))
+
+	events, err = store.Events(ctx, EventFilter{Types: []EventType{EventDonationCreated, EventDonationRefunded}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"evt_2", "evt_3"}, eventIDs(events))
+
+	events, err = store.Events(ctx, EventFilter{Created: TimeRange{Before: time.Unix(300, 0)}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"evt_1", "evt_2"}, eventIDs(events))
+
+	events, err = store.Events(ctx, EventFilter{IDs: []string{"evt_3"}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"evt_3"}, eventIDs(events))
+
+	require.NoError(t, store.Release(ctx, "evt_2"))
+	require.NoError(t, store.Release(ctx, "evt_unknown"))
+
+	events, err = store.Events(ctx, EventFilter{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"evt_1", "evt_2", "evt_3"}, eventIDs(events), "released events can still be replayed")
+
+	claimed, err = store.Claim(ctx, Event{ID: "evt_2", Type: EventDonationCreated, Created: 200})
+	require.NoError(t, err)
+	assert.True(t, claimed, "released events are claimed again")
+
+	claimed, err = store.Claim(ctx, Event{ID: "evt_2", Type: EventDonationCreated, Created: 200})
+	require.NoError(t, err)
+	assert.False(t, claimed, "a released event is only claimed once")
+}
+
+func TestMemoryEventStore(t *testing.T) {
+	testEventStore(t, NewMemoryEventStore(0, 0))
+}
+
+func TestMemoryEventStoreLimits(t *testing.T) {
+	ctx := context.Background()
+	now := time.Unix(1704067200, 0)
+
+	store := NewMemoryEventStore(2, time.Hour)
+	store.now = func() time.Time { return now }
+
+	claim := func(id string) bool {
+		claimed, err := store.Claim(ctx, Event{ID: id})
+		require.NoError(t, err)
+		return claimed
+	}
+
+	assert.True(t, claim("evt_1"))
+	assert.True(t, claim("evt_2"))
+	assert.False(t, claim("evt_1"), "a redelivery makes evt_1 the most recently delivered")
+	assert.True(t, claim("evt_3"))
+
+	events, err := store.Events(ctx, EventFilter{})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"evt_1", "evt_3"}, eventIDs(events), "evt_2 is evicted as the least recently delivered")
+
+	now = now.Add(30 * time.Minute)
+	assert.False(t, claim("evt_3"))
+
+	now = now.Add(45 * time.Minute)
+	events, err = store.Events(ctx, EventFilter{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"evt_3"}, eventIDs(events), "evt_1 expires an hour after its last delivery")
+	assert.True(t, claim("evt_1"))
+}
+
+func TestFileEventStore(t *testing.T) {
+	testEventStore(t, NewFileEventStore(filepath.Join(t.TempDir(), "events.json"), 0))
+}
+
+func TestFileEventStorePersists(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "events.json")
+	now := time.Unix(1704067200, 0)
+
+	store := NewFileEventStore(path, 24*time.Hour)
+	store.now = func() time.Time { return now }
+
+	claimed, err := store.Claim(ctx, Event{ID: "evt_1", Type: EventDonationCreated})
+	require.NoError(t, err)
+	assert.True(t, claimed)
+
+	reopened := NewFileEventStore(path, 24*time.Hour)
+	reopened.now = func() time.Time { return now.Add(time.Hour) }
+
+	claimed, err = reopened.Claim(ctx, Event{ID: "evt_1", Type: EventDonationCreated})
+	require.NoError(t, err)
+	assert.False(t, claimed)
+
+	reopened.now = func() time.Time { return now.Add(26 * time.Hour) }
+	events, err := reopened.Events(ctx, EventFilter{})
+	require.NoError(t, err)
+	assert.Empty(t, events)
+
+	require.NoError(t, os.WriteFile(path, []byte("{"), 0o600))
+	_, err = reopened.Claim(ctx, Event{ID: "evt_2"})
+	assert.ErrorContains(t, err, "failed to unmarshal events")
+}
+
+func TestDispatcherReplay(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryEventStore(0, 0)
+
+	for _, event := range []Event{
+		{ID: "evt_1", Type: EventDonationCreated, Created: 100},
+		{ID: "evt_2", Type: EventDonationCreated, Created: 200},
+		{ID: "evt_3", Type: EventPersonUpdated, Created: 300},
+	} {
+		_, err := store.Claim(ctx, event)
+		require.NoError(t, err)
+	}
+
+	var replayed []string
+	dispatcher := NewDispatcher()
+	dispatcher.On(EventDonationCreated, func(ctx context.Context, event Event) error {
+		if event.ID == "evt_1" {
+			return assert.AnError
+		}
+
+		replayed = append(replayed, event.ID)
+		return nil
+	})
+
+	count, err := dispatcher.Replay(ctx, store, EventFilter{Types: []EventType{EventDonationCreated}})
+
+	assert.Equal(t, 1, count)
+	assert.Equal(t, []string{"evt_2"}, replayed)
+	assert.ErrorIs(t, err, assert.AnError)
+	assert.ErrorContains(t, err, "failed to replay event evt_1")
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+
+	count, err = dispatcher.Replay(canceled, store, EventFilter{})
+	assert.Zero(t, count)
+	assert.ErrorIs(t, err, context.Canceled)
+}
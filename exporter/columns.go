@@ -0,0 +1,109 @@
+package exporter
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/willmadison/donately"
+)
+
+// column extracts a single exported value from a donation. Values are strings, int64s, bools,
+// or nil when the donation has no value for the column.
+type column func(d donately.Donation, f formatter) any
+
+// DefaultColumns are the columns exported when WithColumns isn't used.
+var DefaultColumns = []string{
+	"id", "donation_date", "amount", "currency", "status",
+	"person.email", "person.first_name", "person.last_name", "campaign.title",
+}
+
+var columns = map[string]column{
+	"id":                             func(d donately.Donation, _ formatter) any { return d.ID },
+	"donation_type":                  func(d donately.Donation, _ formatter) any { return d.DonationType },
+	"processor":                      func(d donately.Donation, _ formatter) any { return d.Processor },
+	"status":                         func(d donately.Donation, _ formatter) any { return d.Status },
+	"livemode":                       func(d donately.Donation, _ formatter) any { return d.Livemode },
+	"donation_date":                  func(d donately.Donation, f formatter) any { return f.date(d.DonationDate) },
+	"created":                        func(d donately.Donation, f formatter) any { return f.date(d.Created) },
+	"updated":                        func(d donately.Donation, f formatter) any { return f.date(d.Updated) },
+	"amount":                         func(d donately.Donation, _ formatter) any { return formatAmount(d.AmountInCents, d.Currency) },
+	"amount_in_cents":                func(d donately.Donation, _ formatter) any { return d.AmountInCents },
+	"currency":                       func(d donately.Donation, _ formatter) any { return strings.ToUpper(d.Currency) },
+	"fee":                            func(d donately.Donation, _ formatter) any { return formatAmount(d.FeeInCents, d.Currency) },
+	"fee_in_cents":                   func(d donately.Donation, _ formatter) any { return d.FeeInCents },
+	"recurring":                      func(d donately.Donation, _ formatter) any { return d.Recurring },
+	"refunded":                       func(d donately.Donation, _ formatter) any { return d.Refunded != nil && *d.Refunded },
+	"anonymous":                      func(d donately.Donation, _ formatter) any { return d.Anonymous },
+	"on_behalf_of":                   func(d donately.Donation, _ formatter) any { return d.OnBehalfOf },
+	"comment":                        func(d donately.Donation, _ formatter) any { return d.Comment },
+	"tracking_codes":                 func(d donately.Donation, _ formatter) any { return d.TrackingCodes },
+	"transaction_id":                 func(d donately.Donation, _ formatter) any { return d.TransactionID },
+	"subscription.id":                func(d donately.Donation, _ formatter) any { return d.Subscription.ID },
+	"campaign.id":                    func(d donately.Donation, _ formatter) any { return d.Campaign.ID },
+	"campaign.title":                 func(d donately.Donation, _ formatter) any { return d.Campaign.Title },
+	"person.id":                      func(d donately.Donation, _ formatter) any { return d.Person.ID },
+	"person.email":                   func(d donately.Donation, _ formatter) any { return d.Person.Email },
+	"person.first_name":              func(d donately.Donation, _ formatter) any { return d.Person.FirstName },
+	"person.last_name":               func(d donately.Donation, _ formatter) any { return d.Person.LastName },
+	"person.phone_number":            func(d donately.Donation, _ formatter) any { return d.Person.PhoneNumber },
+	"person.street_address":          func(d donately.Donation, _ formatter) any { return d.Person.StreetAddress },
+	"person.city":                    func(d donately.Donation, _ formatter) any { return d.Person.City },
+	"person.state":                   func(d donately.Donation, _ formatter) any { return d.Person.State },
+	"person.zip_code":                func(d donately.Donation, _ formatter) any { return d.Person.ZipCode },
+	"person.country":                 func(d donately.Donation, _ formatter) any { return d.Person.Country },
+	"charge_source.brand":            func(d donately.Donation, _ formatter) any { return d.ChargeSource.Brand },
+	"charge_source.last4":            func(d donately.Donation, _ formatter) any { return d.ChargeSource.Last4 },
+	"meta_data.base_amount":          func(d donately.Donation, _ formatter) any { return formatAmount(d.MetaData.BaseAmount, d.Currency) },
+	"meta_data.base_amount_in_cents": func(d donately.Donation, _ formatter) any { return d.MetaData.BaseAmount },
+}
+
+// Columns returns the names of every column that can be exported.
+func Columns() []string {
+	names := make([]string, 0, len(columns))
+	for name := range columns {
+		names = append(names, name)
+	}
+
+	slices.Sort(names)
+
+	return names
+}
+
+type formatter struct {
+	location   *time.Location
+	dateLayout string
+}
+
+// date formats a Unix timestamp, or returns nil when it is unset.
+func (f formatter) date(timestamp int64) any {
+	if timestamp == 0 {
+		return nil
+	}
+
+	return time.Unix(timestamp, 0).In(f.location).Format(f.dateLayout)
+}
+
+// formatAmount formats an amount in the currency's minor unit as a plain decimal in its major unit,
+// e.g. 125050 USD as "1250.50" and 1250 JPY as "1250".
+func formatAmount(amount int64, currency string) string {
+	digits := donately.MinorUnits(currency)
+
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+
+	s := strconv.FormatInt(amount, 10)
+	if digits == 0 {
+		return sign + s
+	}
+
+	if len(s) <= digits {
+		s = strings.Repeat("0", digits-len(s)+1) + s
+	}
+
+	return sign + s[:len(s)-digits] + "." + s[len(s)-digits:]
+}
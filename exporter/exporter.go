@@ -0,0 +1,238 @@
+// Package exporter streams the donations of a Donately account to CSV or JSON Lines, for
+// loading into accounting and reporting tools.
+//
+// Donations are fetched a page at a time and written as they arrive, so exports of any size
+// run in constant memory. Nested records are flattened into dotted columns such as
+// "person.email" and "charge_source.last4", amounts are written as decimals in the major unit
+// of each donation's currency, and timestamps are written in a chosen time zone.
+package exporter
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/willmadison/donately"
+)
+
+// Option configures an Exporter.
+type Option func(*Exporter)
+
+// WithColumns returns an Option that sets the columns exported, in order. See Columns for the names
+// available. If not provided, DefaultColumns are exported.
+func WithColumns(names ...string) Option {
+	return func(e *Exporter) {
+		e.columnNames = names
+	}
+}
+
+// WithLocation returns an Option that sets the time zone dates are written in.
+// If not provided, defaults to UTC.
+func WithLocation(location *time.Location) Option {
+	return func(e *Exporter) {
+		e.format.location = location
+	}
+}
+
+// WithDateLayout returns an Option that sets the layout, as accepted by time.Format, dates are
+// written with. If not provided, defaults to time.RFC3339.
+func WithDateLayout(layout string) Option {
+	return func(e *Exporter) {
+		e.format.dateLayout = layout
+	}
+}
+
+// WithFormulaEscaping returns an Option that controls whether WriteCSV neutralizes text that
+// spreadsheet applications would run as a formula: cells starting with "=", "+", "-", "@", a tab
+// or a carriage return are prefixed with a single quote, unless they are numbers. Text such as
+// comments and names is entered by donors, so a crafted value could otherwise run in the
+// spreadsheet of whoever opens the export. If not provided, defaults to true.
+func WithFormulaEscaping(enabled bool) Option {
+	return func(e *Exporter) {
+		e.rawCSV = !enabled
+	}
+}
+
+// WithPageOptions returns an Option that sets how donations are paged through, for example to
+// resume an interrupted export from a donately.CheckpointStore.
+func WithPageOptions(opts donately.PageOptions) Option {
+	return func(e *Exporter) {
+		e.pageOptions = opts
+	}
+}
+
+// Exporter writes the donations of a Donately account to CSV or JSON Lines.
+type Exporter struct {
+	client      donately.Client
+	account     donately.Account
+	columnNames []string
+	columns     []column
+	format      formatter
+	pageOptions donately.PageOptions
+	rawCSV      bool
+}
+
+// New returns an Exporter for the donations of account. An error is returned when an unknown
+// column is requested.
+func New(client donately.Client, account donately.Account, options ...Option) (*Exporter, error) {
+	e := &Exporter{
+		client:      client,
+		account:     account,
+		columnNames: DefaultColumns,
+		format:      formatter{location: time.UTC, dateLayout: time.RFC3339},
+	}
+
+	for _, option := range options {
+		option(e)
+	}
+
+	if len(e.columnNames) == 0 {
+		return nil, fmt.Errorf("no columns to export")
+	}
+
+	for _, name := range e.columnNames {
+		column, ok := columns[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown column %q", name)
+		}
+
+		e.columns = append(e.columns, column)
+	}
+
+	return e, nil
+}
+
+// WriteCSV writes a header followed by one row per donation to w, and returns the number of
+// donations written. Values that are unset are written as empty fields, and text that could run as
+// a spreadsheet formula is escaped (see WithFormulaEscaping).
+func (e *Exporter) WriteCSV(ctx context.Context, w io.Writer) (int, error) {
+	writer := csv.NewWriter(w)
+
+	if err := writer.Write(e.columnNames); err != nil {
+		return 0, fmt.Errorf("failed to write CSV header: %w", err)
+	}
+
+	record := make([]string, len(e.columns))
+
+	count, err := e.each(ctx, func(donation donately.Donation) error {
+		for i, column := range e.columns {
+			record[i] = csvValue(column(donation, e.format))
+			if !e.rawCSV {
+				record[i] = escapeFormula(record[i])
+			}
+		}
+
+		if err := writer.Write(record); err != nil {
+			return fmt.Errorf("failed to write CSV: %w", err)
+		}
+
+		return nil
+	})
+
+	writer.Flush()
+	if err == nil {
+		if flushErr := writer.Error(); flushErr != nil {
+			err = fmt.Errorf("failed to write CSV: %w", flushErr)
+		}
+	}
+
+	return count, err
+}
+
+// WriteJSONLines writes one JSON object per donation to w, with keys in column order, and returns
+// the number of donations written. Values that are unset are written as null.
+func (e *Exporter) WriteJSONLines(ctx context.Context, w io.Writer) (int, error) {
+	writer := bufio.NewWriter(w)
+
+	var line bytes.Buffer
+
+	count, err := e.each(ctx, func(donation donately.Donation) error {
+		line.Reset()
+		line.WriteByte('{')
+
+		for i, column := range e.columns {
+			if i > 0 {
+				line.WriteByte(',')
+			}
+
+			key, _ := json.Marshal(e.columnNames[i])
+			value, err := json.Marshal(column(donation, e.format))
+			if err != nil {
+				return fmt.Errorf("failed to marshal %s: %w", e.columnNames[i], err)
+			}
+
+			line.Write(key)
+			line.WriteByte(':')
+			line.Write(value)
+		}
+
+		line.WriteString("}\n")
+
+		if _, err := writer.Write(line.Bytes()); err != nil {
+			return fmt.Errorf("failed to write JSON Lines: %w", err)
+		}
+
+		return nil
+	})
+
+	if flushErr := writer.Flush(); err == nil && flushErr != nil {
+		err = fmt.Errorf("failed to write JSON Lines: %w", flushErr)
+	}
+
+	return count, err
+}
+
+// each calls write for every donation of the account, stopping at the first error.
+func (e *Exporter) each(ctx context.Context, write func(donately.Donation) error) (int, error) {
+	count := 0
+
+	for donation, err := range e.client.AllDonations(ctx, e.account, e.pageOptions) {
+		if err != nil {
+			return count, fmt.Errorf("failed to list donations: %w", err)
+		}
+
+		if err := write(donation); err != nil {
+			return count, err
+		}
+
+		count++
+	}
+
+	return count, nil
+}
+
+func csvValue(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case bool:
+		return strconv.FormatBool(v)
+	}
+
+	return fmt.Sprint(value)
+}
+
+// escapeFormula prefixes value with a single quote when a spreadsheet application would treat it
+// as a formula. Numbers, including negative amounts, are left as they are.
+func escapeFormula(value string) string {
+	if value == "" || !strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return value
+	}
+
+	if _, err := strconv.ParseFloat(value, 64); err == nil {
+		return value
+	}
+
+	return "'" + value
+}
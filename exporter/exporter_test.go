@@ -0,0 +1,239 @@
+package exporter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/willmadison/donately"
+)
+
+func setup(t *testing.T, donations []donately.Donation) (donately.Client, *[]string) {
+	var requests []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		params := r.URL.Query()
+		requests = append(requests, params.Get("offset"))
+
+		if params.Get("account_id") != "acc_123" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		offset, _ := strconv.Atoi(params.Get("offset"))
+		limit, _ := strconv.Atoi(params.Get("limit"))
+
+		page := []donately.Donation{}
+		for i := offset; i < len(donations) && i < offset+limit; i++ {
+			page = append(page, donations[i])
+		}
+
+		raw, _ := json.Marshal(page)
+		json.NewEncoder(w).Encode(donately.APIResponse{
+			Data:    raw,
+			Summary: &donately.Summary{TotalCount: len(donations), Offset: offset, Limit: limit, HasMore: offset+len(page) < len(donations)},
+		})
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := donately.NewDonatelyClient(donately.WithAPIKey("test-api-key"), donately.WithBaseURL(server.URL))
+	require.NoError(t, err)
+
+	return client, &requests
+}
+
+var refunded = true
+
+var testDonations = []donately.Donation{
+	{
+		ID:            "don_1",
+		Status:        "processed",
+		DonationDate:  time.Date(2024, 3, 15, 2, 30, 0, 0, time.UTC).Unix(),
+		AmountInCents: 125050,
+		Currency:      "usd",
+		FeeInCents:    3657,
+		MetaData:      donately.MetaData{BaseAmount: 121393},
+		Person:        donately.Person{Email: "jane@example.com", FirstName: "Jane", LastName: "Doe, Jr."},
+		Campaign:      donately.Campaign{ID: "camp_1", Title: "Spring Drive"},
+		ChargeSource:  donately.ChargeSource{Brand: "Visa", Last4: "4242"},
+	},
+	{
+		ID:            "don_2",
+		Status:        "refunded",
+		AmountInCents: 5000,
+		Currency:      "JPY",
+		Refunded:      &refunded,
+		Recurring:     true,
+	},
+	{
+		ID:            "don_3",
+		AmountInCents: 7,
+		Currency:      "KWD",
+	},
+}
+
+func TestWriteCSV(t *testing.T) {
+	client, requests := setup(t, testDonations)
+
+	eastern, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	exporter, err := New(client, donately.Account{ID: "acc_123"},
+		WithColumns("id", "donation_date", "amount", "currency", "fee", "meta_data.base_amount", "person.last_name",
+			"campaign.title", "charge_source.brand", "charge_source.last4", "refunded", "recurring"),
+		WithLocation(eastern),
+		WithDateLayout("2006-01-02 15:04"),
+		WithPageOptions(donately.PageOptions{PageSize: 2}),
+	)
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	count, err := exporter.WriteCSV(context.Background(), &out)
+	require.NoError(t, err)
+
+	assert.Equal(t, 3, count)
+	assert.Equal(t, []string{"", "2"}, *requests)
+
+	want := strings.Join([]string{
+		"id,donation_date,amount,currency,fee,meta_data.base_amount,person.last_name,campaign.title,charge_source.brand,charge_source.last4,refunded,recurring",
+		`don_1,2024-03-14 22:30,1250.50,USD,36.57,1213.93,"Doe, Jr.",Spring Drive,Visa,4242,false,false`,
+		"don_2,,5000,JPY,0,0,,,,,true,true",
+		"don_3,,0.007,KWD,0.000,0.000,,,,,false,false",
+	}, "\n") + "\n"
+
+	assert.Equal(t, want, out.String())
+}
+
+func TestWriteJSONLines(t *testing.T) {
+	client, _ := setup(t, testDonations[:2])
+
+	exporter, err := New(client, donately.Account{ID: "acc_123"}, WithColumns("id", "donation_date", "amount", "amount_in_cents", "refunded"))
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	count, err := exporter.WriteJSONLines(context.Background(), &out)
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, count)
+	assert.Equal(t,
+		`{"id":"don_1","donation_date":"2024-03-15T02:30:00Z","amount":"1250.50","amount_in_cents":125050,"refunded":false}`+"\n"+
+			`{"id":"don_2","donation_date":null,"amount":"5000","amount_in_cents":5000,"refunded":true}`+"\n",
+		out.String())
+}
+
+func TestDefaultColumns(t *testing.T) {
+	client, _ := setup(t, testDonations[:1])
+
+	exporter, err := New(client, donately.Account{ID: "acc_123"})
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	_, err = exporter.WriteCSV(context.Background(), &out)
+	require.NoError(t, err)
+
+	header, _, _ := strings.Cut(out.String(), "\n")
+	assert.Equal(t, strings.Join(DefaultColumns, ","), header)
+	assert.Subset(t, Columns(), DefaultColumns)
+}
+
+func TestNewRejectsUnknownColumns(t *testing.T) {
+	client, _ := setup(t, nil)
+
+	_, err := New(client, donately.Account{ID: "acc_123"}, WithColumns("id", "person.nickname"))
+	assert.ErrorContains(t, err, `unknown column "person.nickname"`)
+
+	_, err = New(client, donately.Account{ID: "acc_123"}, WithColumns())
+	assert.Error(t, err)
+}
+
+func TestWriteCSVReportsListErrors(t *testing.T) {
+	client, _ := setup(t, testDonations)
+
+	exporter, err := New(client, donately.Account{ID: "acc_unknown"})
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	count, err := exporter.WriteCSV(context.Background(), &out)
+
+	assert.Zero(t, count)
+	assert.ErrorIs(t, err, donately.ErrNotFound)
+	assert.Equal(t, strings.Join(DefaultColumns, ",")+"\n", out.String())
+}
+
+func TestWriteCSVEscapesFormulas(t *testing.T) {
+	client, _ := setup(t, []donately.Donation{{
+		ID:            "don_1",
+		AmountInCents: -1999,
+		Currency:      "USD",
+		Comment:       `=HYPERLINK("https://evil.example","Click")`,
+		OnBehalfOf:    "@SUM(1+1)",
+		Person:        donately.Person{FirstName: "-Jane", LastName: "+1"},
+	}})
+
+	columns := WithColumns("amount", "comment", "on_behalf_of", "person.first_name", "person.last_name")
+
+	exporter, err := New(client, donately.Account{ID: "acc_123"}, columns)
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	_, err = exporter.WriteCSV(context.Background(), &out)
+	require.NoError(t, err)
+
+	assert.Equal(t, "amount,comment,on_behalf_of,person.first_name,person.last_name\n"+
+		`-19.99,"'=HYPERLINK(""https://evil.example"",""Click"")",'@SUM(1+1),'-Jane,+1`+"\n", out.String())
+
+	exporter, err = New(client, donately.Account{ID: "acc_123"}, columns, WithFormulaEscaping(false))
+	require.NoError(t, err)
+
+	out.Reset()
+	_, err = exporter.WriteCSV(context.Background(), &out)
+	require.NoError(t, err)
+	assert.Contains(t, out.String(), `"=HYPERLINK(`)
+}
+
+func TestEscapeFormula(t *testing.T) {
+	tests := map[string]string{
+		"":         "",
+		"Jane":     "Jane",
+		"=1+1":     "'=1+1",
+		"+cmd":     "'+cmd",
+		"-2+3":     "'-2+3",
+		"@SUM(A1)": "'@SUM(A1)",
+		"\tx":      "'\tx",
+		"\r=1":     "'\r=1",
+		"-19.99":   "-19.99",
+		"+5":       "+5",
+		"a=b":      "a=b",
+	}
+
+	for value, want := range tests {
+		assert.Equal(t, want, escapeFormula(value), value)
+	}
+}
+
+func TestFormatAmount(t *testing.T) {
+	tests := []struct {
+		amount   int64
+		currency string
+		want     string
+	}{
+		{0, "USD", "0.00"},
+		{5, "USD", "0.05"},
+		{-1999, "EUR", "-19.99"},
+		{1250, "jpy", "1250"},
+		{1500, "BHD", "1.500"},
+		{100, "", "1.00"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, formatAmount(tt.amount, tt.currency), "%d %s", tt.amount, tt.currency)
+	}
+}
@@ -0,0 +1,79 @@
+package donately
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+)
+
+const idempotencyKeyHeader = "Idempotency-Key"
+
+type idempotencyKeyContextKey struct{}
+
+// WithIdempotencyKey returns a copy of ctx that carries the given idempotency key.
+// Mutating requests made with the returned context send a key derived from this one and the
+// request's method, endpoint and body instead of a generated one. Each call therefore gets its own
+// key, while repeating the same call with the same key sends the same one again, which lets callers
+// reuse a key across separate attempts of the same logical operation (e.g. a job that is re-run
+// after a crash).
+func WithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKeyContextKey{}, key)
+}
+
+// IdempotencyKeyFromContext returns the idempotency key carried by ctx, if any.
+func IdempotencyKeyFromContext(ctx context.Context) (string, bool) {
+	key, ok := ctx.Value(idempotencyKeyContextKey{}).(string)
+	return key, ok && key != ""
+}
+
+// WithIdempotencyKeyGenerator returns a ClientOption that sets the function used to generate
+// idempotency keys for mutating requests that were not given one via WithIdempotencyKey.
+// A generator that returns an empty string disables idempotency keys for such requests.
+// If not provided, a random 128-bit hex encoded key is generated.
+func WithIdempotencyKeyGenerator(generate func() string) ClientOption {
+	return func(opt *clientOption) {
+		opt.newIdempotencyKey = generate
+	}
+}
+
+// idempotencyKey returns the key to send with a request, reusing the same key for every
+// retry attempt of a single logical call. Safe methods do not need a key.
+func (c *donatelyClient) idempotencyKey(ctx context.Context, req Request) string {
+	switch req.Method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+	default:
+		return ""
+	}
+
+	if key, ok := IdempotencyKeyFromContext(ctx); ok {
+		return callIdempotencyKey(key, req)
+	}
+
+	if c.opts.newIdempotencyKey != nil {
+		return c.opts.newIdempotencyKey()
+	}
+
+	return newIdempotencyKey()
+}
+
+// callIdempotencyKey scopes a key supplied via WithIdempotencyKey to a single call, so the
+// different requests made with one context don't share it.
+func callIdempotencyKey(key string, req Request) string {
+	h := sha256.New()
+	for _, part := range []string{key, req.Method, req.Endpoint} {
+		h.Write([]byte(part))
+		h.Write([]byte{0})
+	}
+
+	h.Write(req.Body)
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func newIdempotencyKey() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
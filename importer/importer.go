@@ -0,0 +1,305 @@
+// Package importer loads people and donations into Donately from CSV files, such as exports
+// of offline gifts or event sign-up sheets.
+//
+// Each row describes a person, identified by email address, and optionally a donation made by
+// them. People are deduplicated by email, both within the file and against the people already
+// on the account, so importing the same donor twice updates their record instead of creating a
+// duplicate.
+package importer
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/willmadison/donately"
+)
+
+// Status is the outcome of importing a single row.
+type Status string
+
+const (
+	// StatusCreated means the row created a person or donation.
+	StatusCreated Status = "created"
+
+	// StatusUpdated means the row updated an existing person.
+	StatusUpdated Status = "updated"
+
+	// StatusSkipped means the row matched an existing person and had nothing to change.
+	StatusSkipped Status = "skipped"
+
+	// StatusFailed means the row was invalid or could not be saved. The Reason says why.
+	StatusFailed Status = "failed"
+)
+
+// RowResult is the outcome of importing a single row.
+type RowResult struct {
+	// Line is the line of the row in the CSV file, counting the header as line 1.
+	Line int
+
+	// Status is the outcome of the row.
+	Status Status
+
+	// Reason explains why the row was skipped or failed.
+	Reason string
+
+	// Email is the email address of the row's person.
+	Email string
+
+	// PersonID is the ID of the person created, updated or matched by the row.
+	// It is empty for people that would be created during a dry run.
+	PersonID string
+
+	// DonationID is the ID of the donation created by the row, if any.
+	// It is always empty during a dry run.
+	DonationID string
+}
+
+// Report is the outcome of an import, one result per row in file order.
+type Report struct {
+	// DryRun reports whether the import ran without saving anything.
+	DryRun bool
+
+	// Rows holds the result of each row.
+	Rows []RowResult
+}
+
+// Count returns the number of rows with the given status.
+func (r Report) Count(status Status) int {
+	n := 0
+	for _, row := range r.Rows {
+		if row.Status == status {
+			n++
+		}
+	}
+
+	return n
+}
+
+// Option configures an Importer.
+type Option func(*Importer)
+
+// WithDryRun returns an Option that validates rows and looks up existing people without saving
+// anything, so the report shows what an import would do.
+func WithDryRun() Option {
+	return func(i *Importer) {
+		i.dryRun = true
+	}
+}
+
+// WithDateLayouts returns an Option that sets the layouts, as accepted by time.Parse, tried in
+// order when parsing donation dates. If not provided, RFC 3339, "2006-01-02" and "01/02/2006" are tried.
+func WithDateLayouts(layouts ...string) Option {
+	return func(i *Importer) {
+		i.dateLayouts = layouts
+	}
+}
+
+// WithLocation returns an Option that sets the time zone of donation dates that don't include one.
+// If not provided, defaults to UTC.
+func WithLocation(location *time.Location) Option {
+	return func(i *Importer) {
+		i.location = location
+	}
+}
+
+// WithCurrency returns an Option that sets the currency of donations whose row doesn't specify one.
+// If not provided, the account's default currency is used.
+func WithCurrency(currency string) Option {
+	return func(i *Importer) {
+		i.currency = currency
+	}
+}
+
+// Importer imports people and donations into a Donately account from CSV.
+type Importer struct {
+	client      donately.Client
+	account     donately.Account
+	mapping     Mapping
+	dryRun      bool
+	dateLayouts []string
+	location    *time.Location
+	currency    string
+}
+
+// New returns an Importer that saves rows to account using client, reading columns according to mapping.
+func New(client donately.Client, account donately.Account, mapping Mapping, options ...Option) *Importer {
+	i := &Importer{
+		client:      client,
+		account:     account,
+		mapping:     mapping,
+		dateLayouts: []string{time.RFC3339, "2006-01-02", "01/02/2006"},
+		location:    time.UTC,
+	}
+
+	for _, option := range options {
+		option(i)
+	}
+
+	return i
+}
+
+// Import reads the CSV from r, whose first line must be a header, and imports every row.
+// Problems with individual rows are reported in the returned Report; an error is only returned
+// when the file itself can't be read, its header doesn't match the mapping, or ctx is done.
+func (i *Importer) Import(ctx context.Context, r io.Reader) (Report, error) {
+	report := Report{DryRun: i.dryRun}
+
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return report, fmt.Errorf("failed to read CSV header: empty file")
+	}
+	if err != nil {
+		return report, fmt.Errorf("failed to read CSV header: %w", err)
+	}
+
+	columns, err := i.mapping.columns(header)
+	if err != nil {
+		return report, err
+	}
+
+	people := map[string]donately.Person{}
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return report, err
+		}
+
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return report, nil
+		}
+
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			report.Rows = append(report.Rows, RowResult{Line: parseErr.StartLine, Status: StatusFailed, Reason: parseErr.Err.Error()})
+			continue
+		}
+		if err != nil {
+			return report, fmt.Errorf("failed to read CSV: %w", err)
+		}
+
+		result := i.importRow(ctx, columns.row(record), people)
+		result.Line, _ = reader.FieldPos(0)
+		report.Rows = append(report.Rows, result)
+	}
+}
+
+func (i *Importer) importRow(ctx context.Context, values map[Field]string, people map[string]donately.Person) RowResult {
+	row, err := i.parse(values)
+	if err != nil {
+		return RowResult{Status: StatusFailed, Reason: err.Error(), Email: values[FieldEmail]}
+	}
+
+	result := RowResult{Email: row.person.Email}
+
+	key := strings.ToLower(row.person.Email)
+
+	existing, found := people[key]
+	if !found {
+		existing, err = i.client.FindPersonByEmail(ctx, row.person.Email, i.account)
+		switch {
+		case err == nil:
+			found = true
+		case !errors.Is(err, donately.ErrNotFound):
+			return failed(result, fmt.Errorf("failed to look up person: %w", err))
+		}
+	}
+
+	person := &row.person
+	status := StatusCreated
+
+	if found {
+		person = merge(existing, row.person)
+		status = StatusUpdated
+
+		if person == nil {
+			person = &existing
+			status = StatusSkipped
+			result.Reason = "person is unchanged"
+		}
+	}
+
+	if status != StatusSkipped && !i.dryRun {
+		person.Accounts = []donately.Account{i.account}
+
+		saved, err := i.client.SavePerson(ctx, *person)
+		if err != nil {
+			return failed(result, fmt.Errorf("failed to save person: %w", err))
+		}
+
+		person = &saved
+	}
+
+	people[key] = *person
+	result.PersonID = person.ID
+	result.Status = status
+
+	if row.donation == nil {
+		return result
+	}
+
+	// A donation row creates a donation whether or not the person changed.
+	result.Status = StatusCreated
+	result.Reason = ""
+
+	if i.dryRun {
+		return result
+	}
+
+	row.donation.Account = i.account
+	row.donation.Person = *person
+
+	donation, err := i.client.SaveDonation(ctx, *row.donation)
+	if err != nil {
+		return failed(result, fmt.Errorf("failed to save donation: %w", err))
+	}
+
+	result.DonationID = donation.ID
+
+	return result
+}
+
+func failed(result RowResult, err error) RowResult {
+	result.Status = StatusFailed
+	result.Reason = err.Error()
+	return result
+}
+
+// merge returns existing updated with the non-empty contact details of incoming,
+// or nil when that changes nothing.
+func merge(existing, incoming donately.Person) *donately.Person {
+	merged := existing
+	changed := false
+
+	update := func(field *string, value string) {
+		if value != "" && *field != value {
+			*field = value
+			changed = true
+		}
+	}
+
+	update(&merged.FirstName, incoming.FirstName)
+	update(&merged.LastName, incoming.LastName)
+	update(&merged.PhoneNumber, incoming.PhoneNumber)
+	update(&merged.StreetAddress, incoming.StreetAddress)
+	update(&merged.StreetAddress2, incoming.StreetAddress2)
+	update(&merged.City, incoming.City)
+	update(&merged.State, incoming.State)
+	update(&merged.ZipCode, incoming.ZipCode)
+	update(&merged.Country, incoming.Country)
+
+	if !changed {
+		return nil
+	}
+
+	return &merged
+}
@@ -0,0 +1,348 @@
+package importer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/willmadison/donately"
+)
+
+// fakeDonately is an in-memory stand-in for the people and donations endpoints.
+type fakeDonately struct {
+	mu        sync.Mutex
+	people    map[string]donately.Person
+	donations []donately.Donation
+	saves     int
+}
+
+func newFakeDonately(people ...donately.Person) *fakeDonately {
+	f := &fakeDonately{people: map[string]donately.Person{}}
+	for _, person := range people {
+		f.people[person.ID] = person
+	}
+
+	return f
+}
+
+func (f *fakeDonately) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var data any
+
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/people":
+		matches := []donately.Person{}
+		for _, person := range f.people {
+			if strings.EqualFold(person.Email, r.URL.Query().Get("email")) {
+				matches = append(matches, person)
+			}
+		}
+		data = matches
+
+	case r.Method == http.MethodPost && strings.HasPrefix(r.URL.Path, "/people"):
+		f.saves++
+		r.ParseForm()
+
+		id := strings.TrimPrefix(strings.TrimPrefix(r.URL.Path, "/people"), "/")
+		if id == "" {
+			id = fmt.Sprintf("person_%d", len(f.people)+1)
+		}
+
+		person := f.people[id]
+		person.ID = id
+		for field, value := range map[*string]string{
+			&person.Email:     r.PostForm.Get("email"),
+			&person.FirstName: r.PostForm.Get("first_name"),
+			&person.LastName:  r.PostForm.Get("last_name"),
+			&person.City:      r.PostForm.Get("city"),
+		} {
+			if value != "" {
+				*field = value
+			}
+		}
+
+		f.people[id] = person
+		data = person
+
+	case r.Method == http.MethodPost && r.URL.Path == "/donations":
+		f.saves++
+		params := r.URL.Query()
+
+		var amount, date int64
+		fmt.Sscan(params.Get("amount_in_cents"), &amount)
+		fmt.Sscan(params.Get("donation_date"), &date)
+
+		donation := donately.Donation{
+			ID:            fmt.Sprintf("don_%d", len(f.donations)+1),
+			AmountInCents: amount,
+			DonationDate:  date,
+			Currency:      params.Get("currency"),
+			Campaign:      donately.Campaign{ID: params.Get("campaign_id")},
+			Person:        donately.Person{Email: params.Get("email")},
+			Anonymous:     params.Get("anonymous") == "true",
+		}
+
+		f.donations = append(f.donations, donation)
+		data = donation
+
+	default:
+		http.NotFound(w, r)
+		return
+	}
+
+	raw, _ := json.Marshal(data)
+	json.NewEncoder(w).Encode(donately.APIResponse{Data: raw})
+}
+
+func setup(t *testing.T, fake *fakeDonately) donately.Client {
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	client, err := donately.NewDonatelyClient(donately.WithAPIKey("test-api-key"), donately.WithBaseURL(server.URL))
+	require.NoError(t, err)
+
+	return client
+}
+
+const donorsCSV = `Email Address,First,Last,Gift,Date,Campaign,Anonymous
+jane@example.com,Jane,Doe,"1,250.50",03/15/2024,camp_1,no
+jane@example.com,Jane,Doe,25,03/16/2024,camp_1,yes
+bob@example.com,Bob,Smith,,,,
+sam@example.com,Sam,Jones,,,,
+sam@example.com,Samuel,Jones,,,,
+not-an-email,Pat,Lee,10,,,
+kim@example.com,Kim,Park,-5,,,
+lee@example.com,Lee,Chan,,03/15/2024,,
+`
+
+var donorsMapping = Mapping{
+	"Email Address": FieldEmail,
+	"First":         FieldFirstName,
+	"Last":          FieldLastName,
+	"Gift":          FieldAmount,
+	"Date":          FieldDonationDate,
+	"Campaign":      FieldCampaignID,
+	"Anonymous":     FieldAnonymous,
+}
+
+func TestImport(t *testing.T) {
+	fake := newFakeDonately(
+		donately.Person{ID: "person_bob", Email: "Bob@Example.com", FirstName: "Bob", LastName: "Smith"},
+	)
+	client := setup(t, fake)
+
+	eastern, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	importer := New(client, donately.Account{ID: "acc_123"}, donorsMapping, WithLocation(eastern), WithCurrency("USD"))
+
+	report, err := importer.Import(context.Background(), strings.NewReader(donorsCSV))
+	require.NoError(t, err)
+
+	assert.False(t, report.DryRun)
+	require.Len(t, report.Rows, 8)
+
+	want := []struct {
+		status Status
+		reason string
+	}{
+		{StatusCreated, ""},
+		{StatusCreated, ""},
+		{StatusSkipped, "person is unchanged"},
+		{StatusCreated, ""},
+		{StatusUpdated, ""},
+		{StatusFailed, `email "not-an-email" is invalid`},
+		{StatusFailed, `amount "-5" is not a positive amount with at most 2 decimal places`},
+		{StatusFailed, "donation_date is set but the donation amount is missing"},
+	}
+
+	for i, row := range report.Rows {
+		assert.Equal(t, i+2, row.Line, "row %d", i)
+		assert.Equal(t, want[i].status, row.Status, "row %d", i)
+		assert.Equal(t, want[i].reason, row.Reason, "row %d", i)
+	}
+
+	assert.Equal(t, 3, report.Count(StatusCreated))
+	assert.Equal(t, 1, report.Count(StatusUpdated))
+	assert.Equal(t, 1, report.Count(StatusSkipped))
+	assert.Equal(t, 3, report.Count(StatusFailed))
+
+	// Jane is created once and both of her gifts are attached to her.
+	assert.Equal(t, report.Rows[0].PersonID, report.Rows[1].PersonID)
+	assert.Equal(t, "person_bob", report.Rows[2].PersonID)
+	assert.Equal(t, report.Rows[3].PersonID, report.Rows[4].PersonID)
+	assert.Equal(t, "don_1", report.Rows[0].DonationID)
+	assert.Equal(t, "don_2", report.Rows[1].DonationID)
+
+	assert.Len(t, fake.people, 3)
+	assert.Equal(t, "Samuel", fake.people[report.Rows[4].PersonID].FirstName)
+
+	require.Len(t, fake.donations, 2)
+
+	first := fake.donations[0]
+	assert.Equal(t, int64(125050), first.AmountInCents)
+	assert.Equal(t, "USD", first.Currency)
+	assert.Equal(t, "camp_1", first.Campaign.ID)
+	assert.Equal(t, "jane@example.com", first.Person.Email)
+	assert.False(t, first.Anonymous)
+	assert.Equal(t, time.Date(2024, 3, 15, 0, 0, 0, 0, eastern).Unix(), first.DonationDate)
+
+	assert.Equal(t, int64(2500), fake.donations[1].AmountInCents)
+	assert.True(t, fake.donations[1].Anonymous)
+}
+
+func TestImportDryRun(t *testing.T) {
+	fake := newFakeDonately(
+		donately.Person{ID: "person_bob", Email: "bob@example.com", FirstName: "Bob", LastName: "Smith"},
+	)
+	client := setup(t, fake)
+
+	importer := New(client, donately.Account{ID: "acc_123"}, donorsMapping, WithDryRun())
+
+	report, err := importer.Import(context.Background(), strings.NewReader(donorsCSV))
+	require.NoError(t, err)
+
+	assert.True(t, report.DryRun)
+	assert.Equal(t, 3, report.Count(StatusCreated))
+	assert.Equal(t, 1, report.Count(StatusUpdated))
+	assert.Equal(t, 1, report.Count(StatusSkipped))
+	assert.Equal(t, 3, report.Count(StatusFailed))
+
+	assert.Empty(t, report.Rows[0].PersonID)
+	assert.Empty(t, report.Rows[0].DonationID)
+	assert.Equal(t, "person_bob", report.Rows[2].PersonID)
+
+	assert.Zero(t, fake.saves)
+}
+
+func TestImportWithDefaultMapping(t *testing.T) {
+	fake := newFakeDonately()
+	client := setup(t, fake)
+
+	csv := "email,first_name,amount_in_cents,notes\nann@example.com,Ann,5000,ignored\n"
+
+	report, err := New(client, donately.Account{ID: "acc_123"}, nil).Import(context.Background(), strings.NewReader(csv))
+	require.NoError(t, err)
+
+	require.Len(t, report.Rows, 1)
+	assert.Equal(t, StatusCreated, report.Rows[0].Status)
+	require.Len(t, fake.donations, 1)
+	assert.Equal(t, int64(5000), fake.donations[0].AmountInCents)
+	assert.Equal(t, "Ann", fake.people["person_1"].FirstName)
+}
+
+func TestImportRejectsBadHeaders(t *testing.T) {
+	client := setup(t, newFakeDonately())
+
+	tests := map[string]struct {
+		mapping Mapping
+		csv     string
+		err     string
+	}{
+		"empty file":     {nil, "", "empty file"},
+		"missing column": {Mapping{"E-mail": FieldEmail}, "email\n", `column "E-mail" is missing from the CSV header`},
+		"unknown field":  {Mapping{"email": FieldEmail, "x": "nickname"}, "email,x\n", `unknown field "nickname"`},
+		"no email":       {Mapping{"name": FieldFirstName}, "name\n", `no column is mapped to field "email"`},
+		"duplicate":      {Mapping{"a": FieldEmail, "b": FieldEmail}, "a,b\n", `more than one column is mapped to field "email"`},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := New(client, donately.Account{ID: "acc_123"}, tt.mapping).Import(context.Background(), strings.NewReader(tt.csv))
+			assert.ErrorContains(t, err, tt.err)
+		})
+	}
+}
+
+func TestImportReportsSaveFailures(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			json.NewEncoder(w).Encode(donately.APIResponse{Data: json.RawMessage("[]")})
+			return
+		}
+
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		json.NewEncoder(w).Encode(donately.APIResponse{Code: "invalid", Message: "bad person"})
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := donately.NewDonatelyClient(donately.WithAPIKey("test-api-key"), donately.WithBaseURL(server.URL))
+	require.NoError(t, err)
+
+	report, err := New(client, donately.Account{ID: "acc_123"}, nil).Import(context.Background(), strings.NewReader("email\nann@example.com\n"))
+	require.NoError(t, err)
+
+	require.Len(t, report.Rows, 1)
+	assert.Equal(t, StatusFailed, report.Rows[0].Status)
+	assert.Contains(t, report.Rows[0].Reason, "failed to save person")
+	assert.Contains(t, report.Rows[0].Reason, "bad person")
+}
+
+func TestImportScalesAmountsByCurrency(t *testing.T) {
+	fake := newFakeDonately()
+	client := setup(t, fake)
+
+	csv := "email,amount,currency\n" +
+		"ann@example.com,5000,jpy\n" +
+		"ann@example.com,1.500,KWD\n" +
+		"ann@example.com,1250,\n" +
+		"ann@example.com,10.50,USD\n" +
+		"ann@example.com,1250.5,JPY\n"
+
+	report, err := New(client, donately.Account{ID: "acc_123"}, nil, WithCurrency("JPY")).Import(context.Background(), strings.NewReader(csv))
+	require.NoError(t, err)
+
+	require.Len(t, report.Rows, 5)
+	assert.Equal(t, StatusFailed, report.Rows[4].Status)
+	assert.Equal(t, `amount "1250.5" is not a positive amount with at most 0 decimal places`, report.Rows[4].Reason)
+
+	var amounts []string
+	for _, donation := range fake.donations {
+		amounts = append(amounts, fmt.Sprintf("%d %s", donation.AmountInCents, donation.Currency))
+	}
+
+	assert.Equal(t, []string{"5000 JPY", "1500 KWD", "1250 JPY", "1050 USD"}, amounts)
+}
+
+func TestParseCents(t *testing.T) {
+	tests := []struct {
+		value  string
+		digits int
+		want   int64
+	}{
+		{"25", 2, 2500},
+		{"25.5", 2, 2550},
+		{"$1,250.05", 2, 125005},
+		{".99", 2, 99},
+		{"€ 10.00", 2, 1000},
+		{"10.000", 2, 1000},
+		{"¥1,250", 0, 1250},
+		{"1250.00", 0, 1250},
+		{"1.5", 3, 1500},
+		{"0.007", 3, 7},
+	}
+
+	for _, tt := range tests {
+		cents, err := parseCents(tt.value, tt.digits)
+		require.NoError(t, err, tt.value)
+		assert.Equal(t, tt.want, cents, tt.value)
+	}
+
+	for _, value := range []string{"0", "0.00", "1.005", "abc", "-5", "1.2.3", "+5"} {
+		_, err := parseCents(value, 2)
+		assert.Error(t, err, value)
+	}
+
+	_, err := parseCents("1.5", 0)
+	assert.Error(t, err)
+}
@@ -0,0 +1,314 @@
+package importer
+
+import (
+	"errors"
+	"fmt"
+	"net/mail"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/willmadison/donately"
+)
+
+// Field is a person or donation field a CSV column can be imported into.
+type Field string
+
+// Person fields. FieldEmail must always be mapped, since people are matched by email address.
+const (
+	FieldEmail          Field = "email"
+	FieldFirstName      Field = "first_name"
+	FieldLastName       Field = "last_name"
+	FieldPhoneNumber    Field = "phone_number"
+	FieldStreetAddress  Field = "street_address"
+	FieldStreetAddress2 Field = "street_address_2"
+	FieldCity           Field = "city"
+	FieldState          Field = "state"
+	FieldZipCode        Field = "zip_code"
+	FieldCountry        Field = "country"
+)
+
+// Donation fields. A row creates a donation when it has an amount, given either in major units
+// (FieldAmount, e.g. "1,250.00") or in cents (FieldAmountInCents).
+const (
+	FieldAmount        Field = "amount"
+	FieldAmountInCents Field = "amount_in_cents"
+	FieldCurrency      Field = "currency"
+	FieldDonationDate  Field = "donation_date"
+	FieldDonationType  Field = "donation_type"
+	FieldCampaignID    Field = "campaign_id"
+	FieldComment       Field = "comment"
+	FieldOnBehalfOf    Field = "on_behalf_of"
+	FieldAnonymous     Field = "anonymous"
+	FieldTrackingCodes Field = "tracking_codes"
+)
+
+var personFields = []Field{
+	FieldEmail, FieldFirstName, FieldLastName, FieldPhoneNumber, FieldStreetAddress,
+	FieldStreetAddress2, FieldCity, FieldState, FieldZipCode, FieldCountry,
+}
+
+var donationFields = []Field{
+	FieldAmount, FieldAmountInCents, FieldCurrency, FieldDonationDate, FieldDonationType,
+	FieldCampaignID, FieldComment, FieldOnBehalfOf, FieldAnonymous, FieldTrackingCodes,
+}
+
+func isField(field Field) bool {
+	return slices.Contains(personFields, field) || slices.Contains(donationFields, field)
+}
+
+// Mapping maps CSV column headers to the fields they are imported into. Headers are matched
+// case-insensitively, ignoring surrounding whitespace, and unmapped columns are ignored.
+// A nil Mapping imports every column whose header is the name of a field (e.g. "first_name").
+type Mapping map[string]Field
+
+// columns holds the index of the CSV column each mapped field is read from.
+type columns map[Field]int
+
+func (m Mapping) columns(header []string) (columns, error) {
+	index := map[string]int{}
+	for i, name := range header {
+		index[normalizeHeader(name)] = i
+	}
+
+	mapping := m
+	if mapping == nil {
+		mapping = Mapping{}
+		for _, name := range header {
+			if field := Field(normalizeHeader(name)); isField(field) {
+				mapping[name] = field
+			}
+		}
+	}
+
+	cols := columns{}
+	for name, field := range mapping {
+		if !isField(field) {
+			return nil, fmt.Errorf("column %q is mapped to unknown field %q", name, field)
+		}
+
+		i, ok := index[normalizeHeader(name)]
+		if !ok {
+			return nil, fmt.Errorf("column %q is missing from the CSV header", name)
+		}
+
+		if _, ok := cols[field]; ok {
+			return nil, fmt.Errorf("more than one column is mapped to field %q", field)
+		}
+
+		cols[field] = i
+	}
+
+	if _, ok := cols[FieldEmail]; !ok {
+		return nil, fmt.Errorf("no column is mapped to field %q", FieldEmail)
+	}
+
+	return cols, nil
+}
+
+func normalizeHeader(name string) string {
+	return strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\uFEFF")))
+}
+
+// row returns the trimmed value of every mapped field in record. Short records leave fields empty.
+func (c columns) row(record []string) map[Field]string {
+	values := map[Field]string{}
+	for field, i := range c {
+		if i < len(record) {
+			values[field] = strings.TrimSpace(record[i])
+		}
+	}
+
+	return values
+}
+
+// row is a parsed and validated CSV row.
+type row struct {
+	person   donately.Person
+	donation *donately.Donation
+}
+
+func (i *Importer) parse(values map[Field]string) (row, error) {
+	var r row
+
+	email := values[FieldEmail]
+	if email == "" {
+		return r, errors.New("email is missing")
+	}
+
+	address, err := mail.ParseAddress(email)
+	if err != nil || address.Address != email {
+		return r, fmt.Errorf("email %q is invalid", email)
+	}
+
+	r.person = donately.Person{
+		Email:          email,
+		FirstName:      values[FieldFirstName],
+		LastName:       values[FieldLastName],
+		PhoneNumber:    values[FieldPhoneNumber],
+		StreetAddress:  values[FieldStreetAddress],
+		StreetAddress2: values[FieldStreetAddress2],
+		City:           values[FieldCity],
+		State:          values[FieldState],
+		ZipCode:        values[FieldZipCode],
+		Country:        values[FieldCountry],
+	}
+
+	currency := strings.ToUpper(values[FieldCurrency])
+	if currency == "" {
+		currency = i.currency
+	}
+
+	amount, hasAmount, err := parseAmount(values, currency)
+	if err != nil {
+		return r, err
+	}
+
+	if !hasAmount {
+		for _, field := range donationFields {
+			if values[field] != "" {
+				return r, fmt.Errorf("%s is set but the donation amount is missing", field)
+			}
+		}
+
+		return r, nil
+	}
+
+	donation := &donately.Donation{
+		AmountInCents: amount,
+		Currency:      currency,
+		DonationType:  values[FieldDonationType],
+		Campaign:      donately.Campaign{ID: values[FieldCampaignID]},
+		Comment:       values[FieldComment],
+		OnBehalfOf:    values[FieldOnBehalfOf],
+		TrackingCodes: values[FieldTrackingCodes],
+	}
+
+	if value := values[FieldDonationDate]; value != "" {
+		date, err := i.parseDate(value)
+		if err != nil {
+			return r, err
+		}
+
+		donation.DonationDate = date.Unix()
+	}
+
+	if value := values[FieldAnonymous]; value != "" {
+		anonymous, err := parseBool(value)
+		if err != nil {
+			return r, err
+		}
+
+		donation.Anonymous = anonymous
+	}
+
+	r.donation = donation
+
+	return r, nil
+}
+
+// parseAmount returns the donation amount in the minor unit of currency, and whether the row has one.
+func parseAmount(values map[Field]string, currency string) (int64, bool, error) {
+	if value := values[FieldAmountInCents]; value != "" {
+		cents, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || cents <= 0 {
+			return 0, true, fmt.Errorf("amount in cents %q is not a positive whole number", value)
+		}
+
+		return cents, true, nil
+	}
+
+	value := values[FieldAmount]
+	if value == "" {
+		return 0, false, nil
+	}
+
+	cents, err := parseCents(value, donately.MinorUnits(currency))
+	if err != nil {
+		return 0, true, err
+	}
+
+	return cents, true, nil
+}
+
+// parseCents converts an amount in major units such as "$1,250.5" to an amount in a minor unit
+// with the given number of decimal places, without going through floating point, so amounts are
+// never rounded. Trailing zeros beyond those decimal places are accepted.
+func parseCents(value string, digits int) (int64, error) {
+	invalid := fmt.Errorf("amount %q is not a positive amount with at most %d decimal places", value, digits)
+
+	cleaned := strings.Map(func(r rune) rune {
+		switch r {
+		case ',', ' ', '$', '€', '£', '¥':
+			return -1
+		}
+		return r
+	}, value)
+
+	whole, fraction, _ := strings.Cut(cleaned, ".")
+	if whole == "" {
+		whole = "0"
+	}
+
+	if len(fraction) > digits {
+		if strings.Trim(fraction[digits:], "0") != "" {
+			return 0, invalid
+		}
+
+		fraction = fraction[:digits]
+	}
+
+	fraction += strings.Repeat("0", digits-len(fraction))
+
+	units, err := strconv.ParseUint(whole, 10, 48)
+	if err != nil {
+		return 0, invalid
+	}
+
+	scale := int64(1)
+	for range digits {
+		scale *= 10
+	}
+
+	var minor uint64
+	if fraction != "" {
+		minor, err = strconv.ParseUint(fraction, 10, 16)
+		if err != nil {
+			return 0, invalid
+		}
+	}
+
+	cents := int64(units)*scale + int64(minor)
+	if cents <= 0 {
+		return 0, invalid
+	}
+
+	return cents, nil
+}
+
+func (i *Importer) parseDate(value string) (time.Time, error) {
+	for _, layout := range i.dateLayouts {
+		if date, err := time.ParseInLocation(layout, value, i.location); err == nil {
+			return date, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("donation date %q doesn't match any accepted layout", value)
+}
+
+func parseBool(value string) (bool, error) {
+	switch strings.ToLower(value) {
+	case "y", "yes":
+		return true, nil
+	case "n", "no":
+		return false, nil
+	}
+
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("anonymous %q is not a yes/no value", value)
+	}
+
+	return b, nil
+}
@@ -0,0 +1,134 @@
+package donately
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+)
+
+// WithLogger returns a ClientOption that logs every attempt of every request made to the
+// Donately API with the given logger. Each attempt is logged with its method, endpoint, status,
+// latency, Donately request ID and attempt number; headers and bodies are additionally logged
+// when the logger is enabled for slog.LevelDebug.
+// Credentials and personal information are redacted unless disabled with WithLogRedaction.
+// If not provided, nothing is logged.
+func WithLogger(logger *slog.Logger) ClientOption {
+	return func(opt *clientOption) {
+		opt.logger = logger
+	}
+}
+
+// WithLogLevels returns a ClientOption that sets the levels at which successful and failed
+// attempts are logged. If not provided, defaults to slog.LevelDebug and slog.LevelWarn.
+func WithLogLevels(success, failure slog.Level) ClientOption {
+	return func(opt *clientOption) {
+		opt.logLevel = success
+		opt.errorLogLevel = failure
+	}
+}
+
+// WithLogRedaction returns a ClientOption that controls whether the bearer token and personal
+// information (emails, phone numbers, addresses, card details) are redacted from logs.
+// If not provided, defaults to true.
+func WithLogRedaction(enabled bool) ClientOption {
+	return func(opt *clientOption) {
+		opt.disableRedaction = !enabled
+	}
+}
+
+func (c *donatelyClient) logRequests(next Handler) Handler {
+	return func(ctx context.Context, req *Request) (*Response, error) {
+		logger := c.opts.logger
+
+		start := time.Now()
+		resp, err := next(ctx, req)
+		latency := time.Since(start)
+
+		attrs := []slog.Attr{
+			slog.String("method", req.Method),
+			slog.String("endpoint", c.redactEndpoint(req.Endpoint)),
+			slog.Int("attempt", req.Attempt),
+			slog.Duration("latency", latency),
+		}
+
+		if resp != nil {
+			attrs = append(attrs, slog.Int("status", resp.StatusCode))
+		}
+
+		if requestID := responseRequestID(resp, err); requestID != "" {
+			attrs = append(attrs, slog.String("request_id", requestID))
+		}
+
+		level := c.opts.logLevel
+		if err != nil {
+			level = c.opts.errorLogLevel
+			attrs = append(attrs, slog.String("error", c.redactError(err)))
+		}
+
+		logger.LogAttrs(ctx, level, "donately request", attrs...)
+
+		if logger.Enabled(ctx, slog.LevelDebug) {
+			debugAttrs := []slog.Attr{
+				slog.String("method", req.Method),
+				slog.String("endpoint", c.redactEndpoint(req.Endpoint)),
+				slog.Int("attempt", req.Attempt),
+				slog.Any("request_headers", c.redactHeader(req)),
+				slog.String("request_body", string(c.redactBody(req.Body, req.ContentType))),
+			}
+
+			if resp != nil {
+				debugAttrs = append(debugAttrs, slog.String("response_body", string(c.redactBody(resp.Body, "application/json"))))
+			}
+
+			logger.LogAttrs(ctx, slog.LevelDebug, "donately request payload", debugAttrs...)
+		}
+
+		return resp, err
+	}
+}
+
+func responseRequestID(resp *Response, err error) string {
+	if resp != nil && resp.APIResponse != nil && resp.APIResponse.RequestID != "" {
+		return resp.APIResponse.RequestID
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.RequestID
+	}
+
+	return ""
+}
+
+func (c *donatelyClient) redactEndpoint(endpoint string) string {
+	if c.opts.disableRedaction {
+		return endpoint
+	}
+
+	return redactEndpoint(endpoint)
+}
+
+func (c *donatelyClient) redactHeader(req *Request) map[string][]string {
+	if c.opts.disableRedaction {
+		return req.Header
+	}
+
+	return redactHeader(req.Header)
+}
+
+func (c *donatelyClient) redactError(err error) string {
+	if c.opts.disableRedaction {
+		return err.Error()
+	}
+
+	return redactError(err)
+}
+
+func (c *donatelyClient) redactBody(body []byte, contentType string) []byte {
+	if c.opts.disableRedaction {
+		return body
+	}
+
+	return redactBody(body, contentType)
+}
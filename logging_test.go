@@ -0,0 +1,179 @@
+package donately
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelInfo}))
+
+	server, client := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(APIResponse{
+			Data:      mustMarshal(t, Account{ID: "acc_123"}),
+			RequestID: "req_123",
+		})
+	}, WithLogger(logger), WithLogLevels(slog.LevelInfo, slog.LevelError))
+	defer server.Close()
+
+	_, err := client.FindAccount(context.Background(), "acc_123")
+	require.NoError(t, err)
+
+	var entry map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+
+	assert.Equal(t, "INFO", entry["level"])
+	assert.Equal(t, "donately request", entry["msg"])
+	assert.Equal(t, "GET", entry["method"])
+	assert.Equal(t, "/accounts/acc_123", entry["endpoint"])
+	assert.Equal(t, float64(200), entry["status"])
+	assert.Equal(t, "req_123", entry["request_id"])
+	assert.Equal(t, float64(1), entry["attempt"])
+	assert.Contains(t, entry, "latency")
+}
+
+func TestWithLoggerLogsFailures(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+
+	server, client := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(APIResponse{
+			Type:      "invalid_request_error",
+			Message:   "not found",
+			Code:      "not_found",
+			RequestID: "req_404",
+		})
+	}, WithLogger(logger))
+	defer server.Close()
+
+	_, err := client.FindAccount(context.Background(), "acc_123")
+	require.Error(t, err)
+
+	var entry map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+
+	assert.Equal(t, "WARN", entry["level"])
+	assert.Equal(t, float64(404), entry["status"])
+	assert.Equal(t, "req_404", entry["request_id"])
+	assert.Contains(t, entry["error"], "not_found")
+}
+
+func TestWithLoggerRedactsErrors(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+
+	server, client := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(`{"data":{"email":"jane@example.com","last4":"4242"}}`))
+	}, WithLogger(logger))
+	defer server.Close()
+
+	_, err := client.FindDonation(context.Background(), "don_123", Account{ID: "acc_123"})
+	require.Error(t, err)
+
+	var entry map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+
+	assert.Equal(t, "WARN", entry["level"])
+	assert.Contains(t, entry["error"], "HTTP error: 500")
+	assert.Contains(t, entry["error"], redacted)
+	assert.NotContains(t, buf.String(), "jane@example.com")
+	assert.NotContains(t, buf.String(), "4242")
+}
+
+func TestRedactError(t *testing.T) {
+	err := fmt.Errorf("failed to save person: %w", &APIError{StatusCode: 422, Code: "invalid", Type: "validation", Message: "jane@example.com is taken"})
+	assert.Equal(t, "failed to save person: API error: invalid - (validation) (HTTP 422)", redactError(err))
+
+	assert.Equal(t, "failed to make request: EOF", redactError(errors.New("failed to make request: EOF")))
+}
+
+func TestWithLoggerRedactsSecrets(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	person := Person{
+		ID:            "person_123",
+		Email:         "john@example.com",
+		StreetAddress: "123 Main St",
+		Accounts:      []Account{{ID: "acc_123"}},
+	}
+
+	server, client := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(APIResponse{Data: mustMarshal(t, Donation{
+			ID:           "don_123",
+			Person:       person,
+			ChargeSource: ChargeSource{Last4: "4242", Fingerprint: "fp_abc"},
+		})})
+	}, WithLogger(logger))
+	defer server.Close()
+
+	_, err := client.SavePerson(context.Background(), person)
+	require.NoError(t, err)
+
+	logs := buf.String()
+	assert.Contains(t, logs, "donately request payload")
+	assert.Contains(t, logs, redacted)
+	assert.NotContains(t, logs, "test-api-key")
+	assert.NotContains(t, logs, "john@example.com")
+	assert.NotContains(t, logs, "john%40example.com")
+	assert.NotContains(t, logs, "123 Main St")
+	assert.NotContains(t, logs, "4242")
+	assert.NotContains(t, logs, "fp_abc")
+}
+
+func TestWithLogRedactionDisabled(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	server, client := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(APIResponse{Data: mustMarshal(t, Person{Email: "me@example.com"})})
+	}, WithLogger(logger), WithLogRedaction(false))
+	defer server.Close()
+
+	_, err := client.Me(context.Background())
+	require.NoError(t, err)
+
+	logs := buf.String()
+	assert.Contains(t, logs, "test-api-key")
+	assert.Contains(t, logs, "me@example.com")
+}
+
+func TestRedactBody(t *testing.T) {
+	t.Run("json", func(t *testing.T) {
+		body := redactBody([]byte(`{"data":[{"email":"a@b.com","internal_id":9007199254740993,"person":{"zip_code":"12345"},"notes":null}]}`), "application/json")
+		assert.JSONEq(t, `{"data":[{"email":"[REDACTED]","internal_id":9007199254740993,"person":{"zip_code":"[REDACTED]"},"notes":null}]}`, string(body))
+	})
+
+	t.Run("form", func(t *testing.T) {
+		body := redactBody([]byte("account_id=acc_123&email=a%40b.com"), "application/x-www-form-urlencoded")
+		assert.Equal(t, "account_id=acc_123&email=%5BREDACTED%5D", string(body))
+	})
+
+	t.Run("malformed form", func(t *testing.T) {
+		body := redactBody([]byte("account_id=acc_123&email=john%40example.com&name=%zz"), "application/x-www-form-urlencoded")
+		assert.Equal(t, "account_id=acc_123&email=%5BREDACTED%5D", string(body))
+	})
+
+	t.Run("other", func(t *testing.T) {
+		body := redactBody([]byte(`retry later for {"email":"a@b.com"}`), "application/json")
+		assert.Equal(t, redacted, string(body))
+	})
+}
+
+func TestRedactEndpoint(t *testing.T) {
+	assert.Equal(t, "/people", redactEndpoint("/people"))
+	assert.Equal(t, "/people?account_id=acc_123&email=%5BREDACTED%5D",
+		redactEndpoint("/people?account_id=acc_123&email=john%40example.com&name=%zz"))
+}
@@ -0,0 +1,70 @@
+package donately
+
+import (
+	"context"
+	"net/http"
+)
+
+// Request describes a single attempt of a call to the Donately API as seen by middleware.
+// Middleware may modify the request (e.g. to add headers) before passing it on.
+type Request struct {
+	// Operation is the name of the Client method that issued the request (e.g. "SaveDonation").
+	Operation string
+
+	// Method is the HTTP method of the request.
+	Method string
+
+	// Endpoint is the path and query of the request, relative to the client's base URL.
+	Endpoint string
+
+	// ContentType is the media type of Body.
+	ContentType string
+
+	// Header holds the headers sent with the request, including authentication.
+	Header http.Header
+
+	// Body is the encoded request body, or nil when the request has none.
+	Body []byte
+
+	// Attempt is the 1-based number of this attempt when retries are enabled.
+	Attempt int
+}
+
+// Response describes the outcome of a single attempt of a call to the Donately API as seen by middleware.
+type Response struct {
+	// StatusCode is the HTTP status code returned by the API.
+	StatusCode int
+
+	// Header holds the response headers.
+	Header http.Header
+
+	// Body is the raw response body.
+	Body []byte
+
+	// APIResponse is the decoded response envelope. It is nil when the body could not be decoded.
+	APIResponse *APIResponse
+}
+
+// Handler sends a Request to the Donately API. When the API answered, the returned Response
+// is non-nil even if an error (such as an *APIError) is also returned.
+type Handler func(context.Context, *Request) (*Response, error)
+
+// Middleware wraps a Handler with additional behavior.
+type Middleware func(Handler) Handler
+
+// WithMiddleware returns a ClientOption that wraps every attempt of every request made to
+// the Donately API with the given middleware. Middleware are applied in order, so the first
+// one is the outermost. Repeated uses of this option append to the chain.
+func WithMiddleware(middleware ...Middleware) ClientOption {
+	return func(opt *clientOption) {
+		opt.middleware = append(opt.middleware, middleware...)
+	}
+}
+
+func chain(handler Handler, middleware ...Middleware) Handler {
+	for i := len(middleware) - 1; i >= 0; i-- {
+		handler = middleware[i](handler)
+	}
+
+	return handler
+}
@@ -0,0 +1,120 @@
+package donately
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMiddlewareOrder(t *testing.T) {
+	var calls []string
+	record := func(name string) Middleware {
+		return func(next Handler) Handler {
+			return func(ctx context.Context, req *Request) (*Response, error) {
+				calls = append(calls, name+" before")
+				resp, err := next(ctx, req)
+				calls = append(calls, name+" after")
+				return resp, err
+			}
+		}
+	}
+
+	server, client := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(APIResponse{Data: mustMarshal(t, Account{ID: "acc_123"})})
+	}, WithMiddleware(record("first"), record("second")), WithMiddleware(record("third")))
+	defer server.Close()
+
+	_, err := client.FindAccount(context.Background(), "acc_123")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"first before", "second before", "third before",
+		"third after", "second after", "first after",
+	}, calls)
+}
+
+func TestMiddlewareSeesRequestAndResponse(t *testing.T) {
+	var seenReq Request
+	var seenResp *Response
+
+	inspect := func(next Handler) Handler {
+		return func(ctx context.Context, req *Request) (*Response, error) {
+			req.Header.Set("X-Audit-ID", "audit-123")
+			seenReq = *req
+
+			resp, err := next(ctx, req)
+			seenResp = resp
+			return resp, err
+		}
+	}
+
+	server, client := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "audit-123", r.Header.Get("X-Audit-ID"))
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(APIResponse{
+			Data:      mustMarshal(t, Person{ID: "person_new"}),
+			RequestID: "req_123",
+		})
+	}, WithMiddleware(inspect))
+	defer server.Close()
+
+	_, err := client.SavePerson(context.Background(), Person{FirstName: "John", Accounts: []Account{{ID: "acc_123"}}})
+	require.NoError(t, err)
+
+	assert.Equal(t, http.MethodPost, seenReq.Method)
+	assert.Equal(t, "/people", seenReq.Endpoint)
+	assert.Equal(t, "application/x-www-form-urlencoded", seenReq.ContentType)
+	assert.Equal(t, "account_id=acc_123&first_name=John", string(seenReq.Body))
+	assert.Equal(t, 1, seenReq.Attempt)
+
+	require.NotNil(t, seenResp)
+	assert.Equal(t, http.StatusOK, seenResp.StatusCode)
+	require.NotNil(t, seenResp.APIResponse)
+	assert.Equal(t, "req_123", seenResp.APIResponse.RequestID)
+}
+
+func TestMiddlewareFaultInjection(t *testing.T) {
+	injected := errors.New("injected fault")
+	var attempts []int
+
+	faulty := func(next Handler) Handler {
+		return func(ctx context.Context, req *Request) (*Response, error) {
+			attempts = append(attempts, req.Attempt)
+			if req.Attempt == 1 {
+				return &Response{StatusCode: http.StatusServiceUnavailable}, &APIError{StatusCode: http.StatusServiceUnavailable}
+			}
+
+			return next(ctx, req)
+		}
+	}
+
+	server, client := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(APIResponse{Data: mustMarshal(t, []Campaign{{ID: "camp_1"}})})
+	}, WithMiddleware(faulty), WithRetry(), WithBackOff(fastBackOff))
+	defer server.Close()
+
+	campaigns, err := client.ListCampaigns(context.Background(), Account{ID: "acc_123"})
+	require.NoError(t, err)
+	assert.Len(t, campaigns, 1)
+	assert.Equal(t, []int{1, 2}, attempts)
+
+	shortCircuit := func(next Handler) Handler {
+		return func(ctx context.Context, req *Request) (*Response, error) {
+			return nil, injected
+		}
+	}
+
+	server, client = setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Error("should not reach the server")
+	}, WithMiddleware(shortCircuit))
+	defer server.Close()
+
+	_, err = client.FindAccount(context.Background(), "acc_123")
+	assert.ErrorIs(t, err, injected)
+}
@@ -0,0 +1,195 @@
+package donately
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+const defaultPageSize = 100
+
+// PageOptions controls how the All* iterators walk a paginated list endpoint.
+type PageOptions struct {
+	// PageSize is the number of records requested per page. Defaults to 100.
+	PageSize int
+
+	// Offset is the number of records to skip before the first page.
+	Offset int
+
+	// Checkpoints, when set, saves the offset of the next page each time a page has been consumed,
+	// so an interrupted iteration resumes from there: a saved offset takes precedence over Offset.
+	// The checkpoint is deleted once the iteration completes. Records of a partially consumed page
+	// are yielded again on resume.
+	Checkpoints CheckpointStore
+}
+
+// Page is a single page of records returned by a paginated list endpoint.
+type Page[T any] struct {
+	// Items holds the records on this page.
+	Items []T
+
+	// TotalCount is the total number of records matching the request, or 0 when the API didn't report it.
+	TotalCount int
+
+	// Offset is the number of records skipped before this page.
+	Offset int
+
+	// Limit is the maximum number of records the page could hold, or 0 when unbounded.
+	Limit int
+
+	// HasMore reports whether another page follows this one.
+	HasMore bool
+}
+
+// NextOffset returns the offset of the page following this one.
+func (p Page[T]) NextOffset() int {
+	return p.Offset + len(p.Items)
+}
+
+// newPage builds a Page from the records and pagination summary of a list response.
+// When the API omits the summary, a full page is assumed to be followed by another. The page keeps
+// the requested offset, since a summary without one reports 0.
+func newPage[T any](items []T, summary *Summary, offset, limit int) Page[T] {
+	page := Page[T]{Items: items, Offset: offset, Limit: limit}
+
+	if summary == nil {
+		page.HasMore = limit > 0 && len(items) >= limit
+		return page
+	}
+
+	page.TotalCount = summary.TotalCount
+	if summary.Limit > 0 {
+		page.Limit = summary.Limit
+	}
+
+	page.HasMore = summary.HasMore || page.NextOffset() < summary.TotalCount
+
+	return page
+}
+
+// listPage fetches a single page of records from a list endpoint scoped to account.
+// Additional query parameters may be passed in params.
+func listPage[T any](ctx context.Context, c *donatelyClient, path string, account Account, params url.Values, offset, limit int) (Page[T], error) {
+	query := url.Values{}
+	for key, values := range params {
+		query[key] = values
+	}
+
+	query.Set("account_id", account.ID)
+
+	if offset > 0 {
+		query.Set("offset", strconv.Itoa(offset))
+	}
+
+	if limit > 0 {
+		query.Set("limit", strconv.Itoa(limit))
+	}
+
+	resp, err := c.makeRequest(ctx, http.MethodGet, path+"?"+query.Encode(), nil)
+	if err != nil {
+		return Page[T]{}, err
+	}
+
+	var items []T
+	if err := json.Unmarshal(resp.Data, &items); err != nil {
+		return Page[T]{}, fmt.Errorf("failed to unmarshal %s: %w", path[1:], err)
+	}
+
+	return newPage(items, resp.Summary, offset, limit), nil
+}
+
+type pageFetcher[T any] func(ctx context.Context, offset, limit int) (Page[T], error)
+
+// paginate walks every page returned by fetch, yielding records one at a time.
+// Iteration ends once a page reports there is nothing more, on the first error, or once ctx is done.
+// When opts.Checkpoints is set, progress is saved under key.
+func paginate[T any](ctx context.Context, opts PageOptions, key CheckpointKey, fetch pageFetcher[T]) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+
+		pageSize := opts.PageSize
+		if pageSize <= 0 {
+			pageSize = defaultPageSize
+		}
+
+		offset := max(opts.Offset, 0)
+
+		if opts.Checkpoints != nil {
+			checkpoint, ok, err := opts.Checkpoints.Load(ctx, key)
+			if err != nil {
+				yield(zero, fmt.Errorf("failed to load checkpoint: %w", err))
+				return
+			}
+
+			if ok {
+				offset = checkpoint.Offset
+			}
+		}
+
+		for {
+			if err := ctx.Err(); err != nil {
+				yield(zero, err)
+				return
+			}
+
+			page, err := fetch(ctx, offset, pageSize)
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+
+			for _, record := range page.Items {
+				if !yield(record, nil) {
+					return
+				}
+			}
+
+			if !page.HasMore || len(page.Items) == 0 {
+				if opts.Checkpoints != nil {
+					if err := opts.Checkpoints.Delete(ctx, key); err != nil {
+						yield(zero, fmt.Errorf("failed to delete checkpoint: %w", err))
+					}
+				}
+
+				return
+			}
+
+			offset = page.NextOffset()
+
+			if opts.Checkpoints != nil {
+				if err := opts.Checkpoints.Save(ctx, key, Checkpoint{Offset: offset}); err != nil {
+					yield(zero, fmt.Errorf("failed to save checkpoint: %w", err))
+					return
+				}
+			}
+		}
+	}
+}
+
+func (c *donatelyClient) AllPeople(ctx context.Context, account Account, opts PageOptions) iter.Seq2[Person, error] {
+	return paginate(ctx, opts, CheckpointKey{AccountID: account.ID, Resource: "people"}, func(ctx context.Context, offset, limit int) (Page[Person], error) {
+		return listPage[Person](withOperation(ctx, "ListPeople"), c, "/people", account, nil, offset, limit)
+	})
+}
+
+func (c *donatelyClient) AllDonations(ctx context.Context, account Account, opts PageOptions) iter.Seq2[Donation, error] {
+	return paginate(ctx, opts, CheckpointKey{AccountID: account.ID, Resource: "donations"}, func(ctx context.Context, offset, limit int) (Page[Donation], error) {
+		return listPage[Donation](withOperation(ctx, "ListDonations"), c, "/donations", account, nil, offset, limit)
+	})
+}
+
+func (c *donatelyClient) AllSubscriptions(ctx context.Context, account Account, opts PageOptions) iter.Seq2[Subscription, error] {
+	return paginate(ctx, opts, CheckpointKey{AccountID: account.ID, Resource: "subscriptions"}, func(ctx context.Context, offset, limit int) (Page[Subscription], error) {
+		return listPage[Subscription](withOperation(ctx, "ListSubscriptions"), c, "/subscriptions", account, nil, offset, limit)
+	})
+}
+
+func (c *donatelyClient) AllCampaigns(ctx context.Context, account Account, opts PageOptions) iter.Seq2[Campaign, error] {
+	return paginate(ctx, opts, CheckpointKey{AccountID: account.ID, Resource: "campaigns"}, func(ctx context.Context, offset, limit int) (Page[Campaign], error) {
+		return listPage[Campaign](withOperation(ctx, "ListCampaigns"), c, "/campaigns", account, nil, offset, limit)
+	})
+}
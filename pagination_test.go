@@ -0,0 +1,307 @@
+package donately
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// pagedHandler serves total records split into pages according to the offset and limit parameters.
+func pagedHandler[T any](t *testing.T, total int, record func(i int) T, requests *[]string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		params := r.URL.Query()
+		*requests = append(*requests, params.Get("offset")+":"+params.Get("limit"))
+
+		offset, _ := strconv.Atoi(params.Get("offset"))
+		limit, _ := strconv.Atoi(params.Get("limit"))
+
+		page := []T{}
+		for i := offset; i < total && i < offset+limit; i++ {
+			page = append(page, record(i))
+		}
+
+		json.NewEncoder(w).Encode(APIResponse{Data: mustMarshal(t, page)})
+	}
+}
+
+func TestAllDonations(t *testing.T) {
+	var requests []string
+	server, client := setupTestServer(t, pagedHandler(t, 25, func(i int) Donation {
+		return Donation{ID: fmt.Sprintf("don_%d", i)}
+	}, &requests))
+	defer server.Close()
+
+	var ids []string
+	for donation, err := range client.AllDonations(context.Background(), Account{ID: "acc_123"}, PageOptions{PageSize: 10}) {
+		require.NoError(t, err)
+		ids = append(ids, donation.ID)
+	}
+
+	assert.Len(t, ids, 25)
+	assert.Equal(t, "don_0", ids[0])
+	assert.Equal(t, "don_24", ids[24])
+	assert.Equal(t, []string{":10", "10:10", "20:10"}, requests)
+}
+
+func TestAllPeopleExactMultipleOfPageSize(t *testing.T) {
+	var requests []string
+	server, client := setupTestServer(t, pagedHandler(t, 4, func(i int) Person {
+		return Person{ID: fmt.Sprintf("person_%d", i)}
+	}, &requests))
+	defer server.Close()
+
+	count := 0
+	for _, err := range client.AllPeople(context.Background(), Account{ID: "acc_123"}, PageOptions{PageSize: 2, Offset: 0}) {
+		require.NoError(t, err)
+		count++
+	}
+
+	assert.Equal(t, 4, count)
+	assert.Equal(t, []string{":2", "2:2", "4:2"}, requests)
+}
+
+func TestAllSubscriptionsAndCampaignsArePaged(t *testing.T) {
+	var requests []string
+	server, client := setupTestServer(t, pagedHandler(t, 3, func(i int) Subscription {
+		return Subscription{ID: fmt.Sprintf("sub_%d", i)}
+	}, &requests))
+	defer server.Close()
+
+	count := 0
+	for _, err := range client.AllSubscriptions(context.Background(), Account{ID: "acc_123"}, PageOptions{PageSize: 2, Offset: 1}) {
+		require.NoError(t, err)
+		count++
+	}
+
+	assert.Equal(t, 2, count)
+	assert.Equal(t, []string{"1:2", "3:2"}, requests)
+
+	requests = nil
+	server, client = setupTestServer(t, pagedHandler(t, 150, func(i int) Campaign {
+		return Campaign{ID: fmt.Sprintf("camp_%d", i)}
+	}, &requests))
+	defer server.Close()
+
+	count = 0
+	for _, err := range client.AllCampaigns(context.Background(), Account{ID: "acc_123"}, PageOptions{}) {
+		require.NoError(t, err)
+		count++
+	}
+
+	assert.Equal(t, 150, count)
+	assert.Equal(t, []string{":100", "100:100"}, requests)
+}
+
+func TestAllDonationsStopsEarly(t *testing.T) {
+	var requests []string
+	server, client := setupTestServer(t, pagedHandler(t, 100, func(i int) Donation {
+		return Donation{ID: fmt.Sprintf("don_%d", i)}
+	}, &requests))
+	defer server.Close()
+
+	count := 0
+	for _, err := range client.AllDonations(context.Background(), Account{ID: "acc_123"}, PageOptions{PageSize: 10}) {
+		require.NoError(t, err)
+		count++
+		if count == 15 {
+			break
+		}
+	}
+
+	assert.Equal(t, 15, count)
+	assert.Len(t, requests, 2)
+}
+
+func TestAllDonationsStopsOnContextCancellation(t *testing.T) {
+	var requests []string
+	server, client := setupTestServer(t, pagedHandler(t, 100, func(i int) Donation {
+		return Donation{ID: fmt.Sprintf("don_%d", i)}
+	}, &requests))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	count := 0
+	var lastErr error
+	for _, err := range client.AllDonations(ctx, Account{ID: "acc_123"}, PageOptions{PageSize: 10}) {
+		if err != nil {
+			lastErr = err
+			break
+		}
+
+		count++
+		if count == 10 {
+			cancel()
+		}
+	}
+
+	assert.Equal(t, 10, count)
+	assert.ErrorIs(t, lastErr, context.Canceled)
+	assert.Len(t, requests, 1)
+}
+
+func TestAllDonationsYieldsErrors(t *testing.T) {
+	server, client := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	})
+	defer server.Close()
+
+	var errs []error
+	for _, err := range client.AllDonations(context.Background(), Account{ID: "acc_123"}, PageOptions{}) {
+		errs = append(errs, err)
+	}
+
+	require.Len(t, errs, 1)
+	assert.ErrorIs(t, errs[0], ErrUnauthorized)
+}
+
+// summaryHandler serves total records like pagedHandler, but also reports Donately's pagination summary.
+func summaryHandler[T any](t *testing.T, total int, record func(i int) T, requests *[]string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		params := r.URL.Query()
+		*requests = append(*requests, params.Get("offset")+":"+params.Get("limit"))
+
+		offset, _ := strconv.Atoi(params.Get("offset"))
+		limit, _ := strconv.Atoi(params.Get("limit"))
+
+		page := []T{}
+		for i := offset; i < total && i < offset+limit; i++ {
+			page = append(page, record(i))
+		}
+
+		json.NewEncoder(w).Encode(APIResponse{
+			Data: mustMarshal(t, page),
+			Summary: &Summary{
+				TotalCount: total,
+				Offset:     offset,
+				Limit:      limit,
+				HasMore:    offset+len(page) < total,
+			},
+		})
+	}
+}
+
+func TestListDonationsPage(t *testing.T) {
+	var requests []string
+	server, client := setupTestServer(t, summaryHandler(t, 25, func(i int) Donation {
+		return Donation{ID: fmt.Sprintf("don_%d", i)}
+	}, &requests))
+	defer server.Close()
+
+	page, err := client.ListDonationsPage(context.Background(), Account{ID: "acc_123"}, 10, 10)
+	require.NoError(t, err)
+
+	assert.Len(t, page.Items, 10)
+	assert.Equal(t, "don_10", page.Items[0].ID)
+	assert.Equal(t, 25, page.TotalCount)
+	assert.Equal(t, 10, page.Offset)
+	assert.Equal(t, 10, page.Limit)
+	assert.True(t, page.HasMore)
+	assert.Equal(t, 20, page.NextOffset())
+
+	page, err = client.ListDonationsPage(context.Background(), Account{ID: "acc_123"}, 20, 10)
+	require.NoError(t, err)
+
+	assert.Len(t, page.Items, 5)
+	assert.False(t, page.HasMore)
+}
+
+func TestListPeoplePageWithoutSummary(t *testing.T) {
+	var requests []string
+	server, client := setupTestServer(t, pagedHandler(t, 4, func(i int) Person {
+		return Person{ID: fmt.Sprintf("person_%d", i)}
+	}, &requests))
+	defer server.Close()
+
+	page, err := client.ListPeoplePage(context.Background(), Account{ID: "acc_123"}, 0, 2)
+	require.NoError(t, err)
+
+	assert.Len(t, page.Items, 2)
+	assert.Zero(t, page.TotalCount)
+	assert.True(t, page.HasMore)
+
+	page, err = client.ListPeoplePage(context.Background(), Account{ID: "acc_123"}, 2, 5)
+	require.NoError(t, err)
+
+	assert.Len(t, page.Items, 2)
+	assert.False(t, page.HasMore)
+}
+
+func TestAllPeopleStopsWhenSummaryHasNoMore(t *testing.T) {
+	var requests []string
+	server, client := setupTestServer(t, summaryHandler(t, 4, func(i int) Person {
+		return Person{ID: fmt.Sprintf("person_%d", i)}
+	}, &requests))
+	defer server.Close()
+
+	count := 0
+	for _, err := range client.AllPeople(context.Background(), Account{ID: "acc_123"}, PageOptions{PageSize: 2}) {
+		require.NoError(t, err)
+		count++
+	}
+
+	assert.Equal(t, 4, count)
+	assert.Equal(t, []string{":2", "2:2"}, requests)
+}
+
+func TestAllCampaignsFollowsSummaryPastShortPages(t *testing.T) {
+	var requests []string
+	server, client := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		requests = append(requests, r.URL.Query().Get("offset"))
+
+		// The API caps pages at 2 records regardless of the requested limit.
+		page := []Campaign{}
+		for i := offset; i < 5 && i < offset+2; i++ {
+			page = append(page, Campaign{ID: fmt.Sprintf("camp_%d", i)})
+		}
+
+		json.NewEncoder(w).Encode(APIResponse{
+			Data:    mustMarshal(t, page),
+			Summary: &Summary{TotalCount: 5, Offset: offset, Limit: 2, HasMore: offset+len(page) < 5},
+		})
+	})
+	defer server.Close()
+
+	count := 0
+	for _, err := range client.AllCampaigns(context.Background(), Account{ID: "acc_123"}, PageOptions{PageSize: 10}) {
+		require.NoError(t, err)
+		count++
+	}
+
+	assert.Equal(t, 5, count)
+	assert.Equal(t, []string{"", "2", "4"}, requests)
+}
+
+func TestAllPeopleKeepsOffsetWhenSummaryOmitsIt(t *testing.T) {
+	var requests []string
+	server, client := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		requests = append(requests, r.URL.Query().Get("offset"))
+		require.Less(t, len(requests), 10, "pagination did not advance")
+
+		page := []Person{}
+		for i := offset; i < 6 && i < offset+2; i++ {
+			page = append(page, Person{ID: fmt.Sprintf("person_%d", i)})
+		}
+
+		fmt.Fprintf(w, `{"data":%s,"summary":{"total_count":6,"limit":2}}`, mustMarshal(t, page))
+	})
+	defer server.Close()
+
+	var ids []string
+	for person, err := range client.AllPeople(context.Background(), Account{ID: "acc_123"}, PageOptions{PageSize: 2}) {
+		require.NoError(t, err)
+		ids = append(ids, person.ID)
+	}
+
+	assert.Equal(t, []string{"person_0", "person_1", "person_2", "person_3", "person_4", "person_5"}, ids)
+	assert.Equal(t, []string{"", "2", "4"}, requests)
+}
@@ -1,5 +1,7 @@
 package donately
 
+import "net/url"
+
 // Person represents a donor or user in the Donately system
 // with their contact information, sign-in details, and associated accounts.
 type Person struct {
@@ -34,3 +36,55 @@ type IPAddress struct {
 	PostalCode *string `json:"postal_code"`
 	SignInTime int64   `json:"sign_in_time"`
 }
+
+// PeopleQuery filters, sorts and pages the people returned by SearchPeople.
+// Zero-valued fields are ignored.
+type PeopleQuery struct {
+	// Email restricts results to people with the given email address.
+	Email string
+
+	// Name restricts results to people whose first or last name matches.
+	Name string
+
+	// Created restricts results to people created within the range.
+	Created TimeRange
+
+	// Updated restricts results to people last updated within the range.
+	Updated TimeRange
+
+	// HasDonated, when set, restricts results to people who have (true) or haven't (false) donated.
+	HasDonated *bool
+
+	// SortBy is the person field to sort by, such as "created" or "last_name".
+	SortBy string
+
+	// Order is the direction results are sorted in. If not provided, the API's default order is used.
+	Order SortOrder
+
+	// Offset is the number of matching people to skip.
+	Offset int
+
+	// Limit is the maximum number of people to return.
+	Limit int
+}
+
+func (q PeopleQuery) validate() error {
+	if err := q.Created.validate("created"); err != nil {
+		return err
+	}
+
+	return q.Updated.validate("updated")
+}
+
+func (q PeopleQuery) values() url.Values {
+	params := url.Values{}
+
+	setString(params, "email", q.Email)
+	setString(params, "name", q.Name)
+	q.Created.set(params, "created")
+	q.Updated.set(params, "updated")
+	setBool(params, "has_donated", q.HasDonated)
+	setSort(params, q.SortBy, q.Order)
+
+	return params
+}
@@ -0,0 +1,285 @@
+package donately
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const defaultPollInterval = time.Minute
+
+// PollerOption configures a Poller.
+type PollerOption func(*Poller)
+
+// WithPollInterval returns a PollerOption that sets how long the Poller waits between the end of
+// one poll and the start of the next. If not provided, defaults to 1 minute.
+func WithPollInterval(interval time.Duration) PollerOption {
+	return func(p *Poller) {
+		p.interval = interval
+	}
+}
+
+// WithPollPageSize returns a PollerOption that sets the number of records fetched per request.
+// If not provided, the default page size of Sync is used.
+func WithPollPageSize(size int) PollerOption {
+	return func(p *Poller) {
+		p.pageSize = size
+	}
+}
+
+// WithPollLogger returns a PollerOption that sets the logger failed polls are reported to by Run.
+// If not provided, defaults to slog.Default().
+func WithPollLogger(logger *slog.Logger) PollerOption {
+	return func(p *Poller) {
+		p.logger = logger
+	}
+}
+
+// Poller produces the events a webhook would deliver by periodically syncing the donations,
+// subscriptions and people of an account, for environments that can't receive webhooks. Each poll
+// only fetches the records changed since the previous one, using SyncDonations, SyncSubscriptions
+// and SyncPeople.
+//
+// A changed record yields a "<resource>.created" event when it was created since the previous poll,
+// and a "<resource>.updated" event otherwise, except for status changes that have their own event
+// type, such as EventDonationRefunded and EventSubscriptionCancelled. Event IDs are derived from the
+// record and its Updated timestamp, so the same change seen by two pollers has the same ID and can
+// be deduplicated with an EventStore.
+//
+// The first poll of each resource only establishes what exists and yields no events. The sync
+// cursors, and the status of each donation and subscription needed to report status changes, are
+// kept in memory. Records deleted are not reported.
+type Poller struct {
+	client   Client
+	account  Account
+	interval time.Duration
+	pageSize int
+	logger   *slog.Logger
+	now      func() time.Time
+
+	mu   sync.Mutex
+	seen map[string]*pollState
+}
+
+// pollState is what a Poller has seen of one resource.
+type pollState struct {
+	cursor SyncCursor
+
+	// statuses holds the last status seen of each record, for resources that have one.
+	statuses map[string]string
+
+	// complete is set once the resource has been synced in full, after which changes are reported.
+	complete bool
+}
+
+// polledRecord is a record synced by a Poller.
+type polledRecord struct {
+	id       string
+	status   string
+	created  int64
+	updated  int64
+	livemode bool
+}
+
+// NewPoller returns a Poller for account.
+func NewPoller(client Client, account Account, options ...PollerOption) *Poller {
+	p := &Poller{
+		client:   client,
+		account:  account,
+		interval: defaultPollInterval,
+		logger:   slog.Default(),
+		now:      time.Now,
+		seen:     map[string]*pollState{},
+	}
+
+	for _, option := range options {
+		option(p)
+	}
+
+	return p
+}
+
+// Run polls the account until ctx is done, passing the events found to handler. When an event
+// handler fails, the record and the changes after it are reported again on the next poll. Failed
+// polls are logged and retried after the interval. Run returns once ctx is done and the event being
+// handled, if any, has been handled.
+func (p *Poller) Run(ctx context.Context, handler EventHandler) {
+	for {
+		if err := p.Poll(ctx, handler); err != nil && ctx.Err() == nil {
+			p.logger.WarnContext(ctx, "donately: poll failed", slog.String("account_id", p.account.ID), slog.Any("error", err))
+		}
+
+		timer := time.NewTimer(p.interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+	}
+}
+
+// Events runs the Poller in the background and returns a channel receiving the events found.
+// Each event is only considered handled once it has been received. The channel is closed once
+// ctx is done and polling has stopped.
+func (p *Poller) Events(ctx context.Context) <-chan Event {
+	events := make(chan Event)
+
+	go func() {
+		defer close(events)
+
+		p.Run(ctx, func(ctx context.Context, event Event) error {
+			select {
+			case events <- event:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		})
+	}()
+
+	return events
+}
+
+// Poll syncs the donations, subscriptions and people of the account once, passing an event to
+// handler for each record created or changed since the previous call. A failure to sync a resource
+// doesn't prevent the others from being polled; the returned error joins every failure.
+func (p *Poller) Poll(ctx context.Context, handler EventHandler) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var errs []error
+
+	for _, poll := range []func() error{
+		func() error {
+			return pollSync(ctx, p, "donation", true, p.client.SyncDonations, func(d Donation) polledRecord {
+				status := d.Status
+				if d.Refunded != nil && *d.Refunded {
+					status = "refunded"
+				}
+
+				return polledRecord{id: d.ID, status: status, created: d.Created, updated: d.Updated, livemode: d.Livemode}
+			}, handler)
+		},
+		func() error {
+			return pollSync(ctx, p, "subscription", true, p.client.SyncSubscriptions, func(s Subscription) polledRecord {
+				return polledRecord{id: s.ID, status: s.Status, created: s.Created, updated: s.Updated, livemode: s.Livemode}
+			}, handler)
+		},
+		func() error {
+			return pollSync(ctx, p, "person", false, p.client.SyncPeople, func(person Person) polledRecord {
+				return polledRecord{id: person.ID, created: person.Created, updated: person.Updated}
+			}, handler)
+		},
+	} {
+		if err := poll(); err != nil {
+			errs = append(errs, err)
+		}
+
+		if ctx.Err() != nil {
+			break
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// pollSync syncs one resource from the Poller's cursor for it, passing an event for each change to
+// handler. The cursor only advances past records whose event was handled.
+func pollSync[T any](ctx context.Context, p *Poller, resource string, hasStatus bool, newSync func(Account, SyncCursor) *Sync[T], describe func(T) polledRecord, handler EventHandler) error {
+	state := p.seen[resource]
+	if state == nil {
+		state = &pollState{}
+		if hasStatus {
+			state.statuses = map[string]string{}
+		}
+
+		p.seen[resource] = state
+	}
+
+	start := state.cursor
+
+	syncer := newSync(p.account, start)
+	syncer.PageSize = p.pageSize
+
+	for v, err := range syncer.Changes(ctx) {
+		if err != nil {
+			return fmt.Errorf("failed to poll %s records: %w", resource, err)
+		}
+
+		record := describe(v)
+
+		previous, known := state.statuses[record.id]
+		if state.complete {
+			event, err := p.event(resource, record, v, start, previous, known)
+			if err != nil {
+				return err
+			}
+
+			if err := handler(ctx, event); err != nil {
+				return fmt.Errorf("failed to handle %s event for %s: %w", event.Type, record.id, err)
+			}
+		}
+
+		state.cursor = syncer.Cursor()
+		if hasStatus {
+			state.statuses[record.id] = record.status
+		}
+	}
+
+	state.complete = true
+
+	return nil
+}
+
+func (p *Poller) event(resource string, record polledRecord, v any, start SyncCursor, previous string, known bool) (Event, error) {
+	eventType := EventType(resource + ".updated")
+
+	switch {
+	case record.created > start.UpdatedAfter || (record.created == start.UpdatedAfter && !slices.Contains(start.IDs, record.id)):
+		// Records that existed at the previous poll were created no later than its cursor, and
+		// those created at the cursor's timestamp were synced by it.
+		eventType = EventType(resource + ".created")
+	case known && record.status != previous:
+		if statusType, ok := statusEventTypes[resource][record.status]; ok {
+			eventType = statusType
+		}
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return Event{}, fmt.Errorf("failed to marshal %s: %w", record.id, err)
+	}
+
+	created := record.updated
+	if created == 0 {
+		created = p.now().Unix()
+	}
+
+	return Event{
+		ID:        "poll_" + record.id + "_" + strconv.FormatInt(record.updated, 10),
+		Type:      eventType,
+		Created:   created,
+		Livemode:  record.livemode,
+		AccountID: p.account.ID,
+		Data:      data,
+	}, nil
+}
+
+// statusEventTypes holds, by resource and new status, the event types reported for status changes.
+var statusEventTypes = map[string]map[string]EventType{
+	"donation": {
+		"refunded": EventDonationRefunded,
+		"failed":   EventDonationFailed,
+	},
+	"subscription": {
+		"cancelled": EventSubscriptionCancelled,
+		"canceled":  EventSubscriptionCancelled,
+	},
+}
@@ -0,0 +1,271 @@
+package donately
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// accountRecords serves mutable lists of donations, subscriptions and people, filtered by
+// updated_after, sorted by Updated and paginated like the API.
+type accountRecords struct {
+	mu            sync.Mutex
+	donations     []Donation
+	subscriptions []Subscription
+	people        []Person
+	failing       string
+	requests      int
+	queries       []string
+}
+
+func (a *accountRecords) update(f func()) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	f()
+}
+
+func (a *accountRecords) handler(w http.ResponseWriter, r *http.Request) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.requests++
+
+	resource := strings.TrimPrefix(r.URL.Path, "/")
+	params := r.URL.Query()
+	a.queries = append(a.queries, resource+"?"+params.Get("updated_after"))
+
+	if resource == a.failing {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	type record struct {
+		value   any
+		updated int64
+	}
+
+	var records []record
+	switch resource {
+	case "donations":
+		for _, d := range a.donations {
+			records = append(records, record{d, d.Updated})
+		}
+	case "subscriptions":
+		for _, s := range a.subscriptions {
+			records = append(records, record{s, s.Updated})
+		}
+	case "people":
+		for _, p := range a.people {
+			records = append(records, record{p, p.Updated})
+		}
+	}
+
+	after, _ := strconv.ParseInt(params.Get("updated_after"), 10, 64)
+	records = slices.DeleteFunc(records, func(r record) bool { return r.updated <= after })
+	slices.SortStableFunc(records, func(a, b record) int { return cmp.Compare(a.updated, b.updated) })
+
+	offset, _ := strconv.Atoi(params.Get("offset"))
+	limit, _ := strconv.Atoi(params.Get("limit"))
+
+	page := []any{}
+	for i := offset; i < len(records) && i < offset+limit; i++ {
+		page = append(page, records[i].value)
+	}
+
+	raw, _ := json.Marshal(page)
+	json.NewEncoder(w).Encode(APIResponse{
+		Data:    raw,
+		Summary: &Summary{TotalCount: len(records), Offset: offset, Limit: limit, HasMore: offset+len(page) < len(records)},
+	})
+}
+
+type recordedEvents struct {
+	events []Event
+}
+
+func (r *recordedEvents) handle(ctx context.Context, event Event) error {
+	r.events = append(r.events, event)
+	return nil
+}
+
+func (r *recordedEvents) types() []string {
+	var types []string
+	for _, event := range r.events {
+		types = append(types, string(event.Type)+" "+event.ID)
+	}
+
+	r.events = nil
+	return types
+}
+
+func TestPollerPoll(t *testing.T) {
+	campaign := Campaign{ID: "camp_1", AmountRaisedInCents: 5000}
+
+	records := &accountRecords{
+		donations: []Donation{
+			{ID: "don_1", Status: "processed", Created: 100, Updated: 100, Campaign: campaign},
+			{ID: "don_2", Status: "processed", Created: 100, Updated: 100, Campaign: campaign},
+		},
+		subscriptions: []Subscription{{ID: "sub_1", Status: "active", Created: 100, Updated: 100}},
+		people:        []Person{{ID: "person_1", Email: "jane@example.com", Created: 100, Updated: 100}},
+	}
+
+	_, client := setupTestServer(t, records.handler)
+	poller := NewPoller(client, Account{ID: "acc_123"}, WithPollPageSize(1))
+	ctx := context.Background()
+
+	var received recordedEvents
+
+	require.NoError(t, poller.Poll(ctx, received.handle))
+	assert.Empty(t, received.types(), "the first poll only records what exists")
+
+	// A new gift changes the campaign totals nested in every donation, which isn't a change to them.
+	records.update(func() {
+		for i := range records.donations {
+			records.donations[i].Campaign.AmountRaisedInCents = 7500
+		}
+	})
+
+	records.queries = nil
+	require.NoError(t, poller.Poll(ctx, received.handle))
+	assert.Empty(t, received.types(), "nothing changed")
+	for _, query := range records.queries {
+		assert.True(t, strings.HasSuffix(query, "?99"), "only changes are fetched: %s", query)
+	}
+
+	records.update(func() {
+		records.donations[0].Refunded = Bool(true)
+		records.donations[0].Updated = 200
+		records.donations[1].Comment = "In memory of Ada"
+		records.donations[1].Updated = 200
+		records.donations = append(records.donations, Donation{ID: "don_3", Status: "processed", Livemode: true, Created: 300, Updated: 300})
+		records.subscriptions[0].Status = "cancelled"
+		records.subscriptions[0].Updated = 200
+		records.people[0].Email = "jane.doe@example.com"
+		records.people[0].Updated = 200
+	})
+
+	require.NoError(t, poller.Poll(ctx, received.handle))
+
+	events := received.events
+	assert.Equal(t, []string{
+		"donation.refunded poll_don_1_200",
+		"donation.updated poll_don_2_200",
+		"donation.created poll_don_3_300",
+		"subscription.cancelled poll_sub_1_200",
+		"person.updated poll_person_1_200",
+	}, received.types())
+
+	created := events[2]
+	assert.Equal(t, "acc_123", created.AccountID)
+	assert.Equal(t, int64(300), created.Created)
+	assert.True(t, created.Livemode)
+
+	donation, err := created.Donation()
+	require.NoError(t, err)
+	assert.Equal(t, "don_3", donation.ID)
+
+	person, err := events[4].Person()
+	require.NoError(t, err)
+	assert.Equal(t, "jane.doe@example.com", person.Email)
+
+	require.NoError(t, poller.Poll(ctx, received.handle))
+	assert.Empty(t, received.types())
+}
+
+func TestPollerRetriesFailures(t *testing.T) {
+	records := &accountRecords{
+		donations: []Donation{{ID: "don_1", Status: "processed", Created: 100, Updated: 100}},
+		people:    []Person{{ID: "person_1", Created: 100, Updated: 100}},
+		failing:   "subscriptions",
+	}
+
+	_, client := setupTestServer(t, records.handler)
+	poller := NewPoller(client, Account{ID: "acc_123"})
+	ctx := context.Background()
+
+	var received recordedEvents
+
+	err := poller.Poll(ctx, received.handle)
+	assert.ErrorContains(t, err, "failed to poll subscription records")
+	assert.ErrorIs(t, err, ErrValidation)
+
+	records.update(func() {
+		records.failing = ""
+		records.donations[0].Status = "failed"
+		records.donations[0].Updated = 200
+		records.subscriptions = []Subscription{{ID: "sub_1", Status: "active", Created: 150, Updated: 150}}
+	})
+
+	errHandler := errors.New("handler failed")
+	err = poller.Poll(ctx, func(ctx context.Context, event Event) error {
+		return errHandler
+	})
+	assert.ErrorIs(t, err, errHandler)
+	assert.ErrorContains(t, err, "failed to handle donation.failed event for don_1")
+
+	require.NoError(t, poller.Poll(ctx, received.handle))
+	assert.Equal(t, []string{"donation.failed poll_don_1_200"}, received.types(),
+		"the failed event is reported again, and subscriptions synced for the first time establish a baseline")
+
+	records.update(func() {
+		records.subscriptions = append(records.subscriptions, Subscription{ID: "sub_2", Status: "active", Created: 150, Updated: 150})
+	})
+
+	require.NoError(t, poller.Poll(ctx, received.handle))
+	assert.Equal(t, []string{"subscription.created poll_sub_2_150"}, received.types(),
+		"a record created in the same second as the cursor is reported as created")
+}
+
+func TestPollerEvents(t *testing.T) {
+	records := &accountRecords{people: []Person{{ID: "person_1", Created: 100, Updated: 100}}}
+
+	_, client := setupTestServer(t, records.handler)
+	poller := NewPoller(client, Account{ID: "acc_123"}, WithPollInterval(10*time.Millisecond))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events := poller.Events(ctx)
+
+	// Once a second poll has started, the first has recorded what exists.
+	require.Eventually(t, func() bool {
+		records.mu.Lock()
+		defer records.mu.Unlock()
+
+		return records.requests > 3
+	}, 5*time.Second, time.Millisecond)
+
+	records.update(func() {
+		records.people = append(records.people, Person{ID: "person_2", Created: 200, Updated: 200})
+	})
+
+	select {
+	case event := <-events:
+		assert.Equal(t, EventPersonCreated, event.Type)
+		assert.Equal(t, "poll_person_2_200", event.ID)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no event received")
+	}
+
+	cancel()
+
+	select {
+	case _, ok := <-events:
+		assert.False(t, ok, "the channel is closed once polling stops")
+	case <-time.After(5 * time.Second):
+		t.Fatal("polling did not stop")
+	}
+}
@@ -0,0 +1,93 @@
+package donately
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// SortOrder is the direction search results are sorted in.
+type SortOrder string
+
+const (
+	// SortAscending sorts results from smallest to largest.
+	SortAscending SortOrder = "asc"
+
+	// SortDescending sorts results from largest to smallest.
+	SortDescending SortOrder = "desc"
+)
+
+// TimeRange restricts a timestamp to a window. A zero bound leaves that side of the window open.
+type TimeRange struct {
+	// After excludes records at or before this time.
+	After time.Time
+
+	// Before excludes records at or after this time.
+	Before time.Time
+}
+
+// IsZero reports whether neither bound is set.
+func (r TimeRange) IsZero() bool {
+	return r.After.IsZero() && r.Before.IsZero()
+}
+
+// contains reports whether t falls within the range.
+func (r TimeRange) contains(t time.Time) bool {
+	return (r.After.IsZero() || t.After(r.After)) && (r.Before.IsZero() || t.Before(r.Before))
+}
+
+func (r TimeRange) validate(field string) error {
+	if !r.After.IsZero() && !r.Before.IsZero() && !r.After.Before(r.Before) {
+		return fmt.Errorf("%w: %s range is empty", ErrValidation, field)
+	}
+
+	return nil
+}
+
+// set adds the range to params as <field>_after and <field>_before Unix timestamps.
+func (r TimeRange) set(params url.Values, field string) {
+	r.format(params, field, func(t time.Time) string {
+		return strconv.FormatInt(t.Unix(), 10)
+	})
+}
+
+// setDates adds the range to params as <field>_after and <field>_before RFC 3339 dates, for
+// fields the API stores as strings rather than timestamps.
+func (r TimeRange) setDates(params url.Values, field string) {
+	r.format(params, field, func(t time.Time) string {
+		return t.UTC().Format(time.RFC3339)
+	})
+}
+
+func (r TimeRange) format(params url.Values, field string, format func(time.Time) string) {
+	if !r.After.IsZero() {
+		params.Set(field+"_after", format(r.After))
+	}
+
+	if !r.Before.IsZero() {
+		params.Set(field+"_before", format(r.Before))
+	}
+}
+
+// Bool returns a pointer to v, for use with the optional boolean filters of query types.
+func Bool(v bool) *bool {
+	return &v
+}
+
+func setString(params url.Values, key, value string) {
+	if value != "" {
+		params.Set(key, value)
+	}
+}
+
+func setBool(params url.Values, key string, value *bool) {
+	if value != nil {
+		params.Set(key, strconv.FormatBool(*value))
+	}
+}
+
+func setSort(params url.Values, sortBy string, order SortOrder) {
+	setString(params, "sort_by", sortBy)
+	setString(params, "order", string(order))
+}
@@ -0,0 +1,190 @@
+package donately
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/cenkalti/backoff/v5"
+)
+
+// WithRateLimit returns a ClientOption that limits the client to rps requests per second with bursts
+// of up to burst requests. The limit is shared by every goroutine using the client, and is also paused
+// whenever the API signals (via Retry-After or rate-limit headers) that the quota has been exhausted.
+// Throttled requests are retried once the requested delay has passed, even without WithRetry, until
+// the budget set by WithMaxAttempts and WithMaxElapsedTime is exhausted; only then is an error
+// matching ErrRateLimited returned. If not provided, requests are not rate limited.
+func WithRateLimit(rps float64, burst int) ClientOption {
+	return func(opt *clientOption) {
+		opt.rateLimit = rps
+		opt.rateLimitBurst = burst
+	}
+}
+
+// rateLimiter is a token bucket that can additionally be paused until a point in time.
+type rateLimiter struct {
+	mu          sync.Mutex
+	rate        float64
+	burst       float64
+	tokens      float64
+	last        time.Time
+	pausedUntil time.Time
+}
+
+func newRateLimiter(rps float64, burst int) *rateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+
+	return &rateLimiter{
+		rate:   rps,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// Wait blocks until a request may be issued or ctx is done.
+func (l *rateLimiter) Wait(ctx context.Context) error {
+	l.mu.Lock()
+	now := time.Now()
+	l.tokens = min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	l.last = now
+	l.tokens--
+
+	var wait time.Duration
+	if l.tokens < 0 {
+		wait = time.Duration(-l.tokens / l.rate * float64(time.Second))
+	}
+
+	if pause := l.pausedUntil.Sub(now); pause > wait {
+		wait = pause
+	}
+	l.mu.Unlock()
+
+	if wait <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		l.mu.Lock()
+		l.tokens++
+		l.mu.Unlock()
+		return ctx.Err()
+	}
+}
+
+// PauseUntil stops requests from being issued before t.
+func (l *rateLimiter) PauseUntil(t time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if t.After(l.pausedUntil) {
+		l.pausedUntil = t
+	}
+}
+
+func (c *donatelyClient) limitRate(next Handler) Handler {
+	return func(ctx context.Context, req *Request) (*Response, error) {
+		if err := c.limiter.Wait(ctx); err != nil {
+			return nil, err
+		}
+
+		resp, err := next(ctx, req)
+
+		if resp != nil {
+			if wait := quotaResetDelay(resp); wait > 0 {
+				c.limiter.PauseUntil(time.Now().Add(wait))
+			}
+		}
+
+		return resp, err
+	}
+}
+
+// quotaResetDelay returns how long the API asked us to hold off, either explicitly with a
+// throttled response or implicitly by reporting that no requests remain in the current window.
+func quotaResetDelay(resp *Response) time.Duration {
+	if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable {
+		return retryAfter(resp.Header)
+	}
+
+	remaining := resp.Header.Get("X-RateLimit-Remaining")
+	if remaining == "" {
+		remaining = resp.Header.Get("RateLimit-Remaining")
+	}
+
+	if remaining == "0" {
+		return retryAfter(resp.Header)
+	}
+
+	return 0
+}
+
+// retryAfter parses the delay requested by the API from the Retry-After header, which may hold
+// either seconds or an HTTP date, falling back to the rate-limit reset headers.
+func retryAfter(h http.Header) time.Duration {
+	if value := h.Get("Retry-After"); value != "" {
+		if seconds, err := strconv.Atoi(value); err == nil {
+			return time.Duration(max(seconds, 0)) * time.Second
+		}
+
+		if at, err := http.ParseTime(value); err == nil {
+			return max(time.Until(at), 0)
+		}
+	}
+
+	for _, key := range []string{"RateLimit-Reset", "X-RateLimit-Reset"} {
+		seconds, err := strconv.ParseInt(h.Get(key), 10, 64)
+		if err != nil || seconds < 0 {
+			continue
+		}
+
+		// Reset headers hold either the delta in seconds or the Unix time the window resets.
+		if seconds > 1_000_000_000 {
+			return max(time.Until(time.Unix(seconds, 0)), 0)
+		}
+
+		return time.Duration(seconds) * time.Second
+	}
+
+	return 0
+}
+
+// retryAfterBackOff waits at least as long as the API asked before the next attempt.
+type retryAfterBackOff struct {
+	backOff backoff.BackOff
+	hint    time.Duration
+}
+
+func (b *retryAfterBackOff) NextBackOff() time.Duration {
+	next := b.backOff.NextBackOff()
+	if next >= 0 && b.hint > next {
+		next = b.hint
+	}
+
+	b.hint = 0
+	return next
+}
+
+func (b *retryAfterBackOff) Reset() {
+	b.hint = 0
+	b.backOff.Reset()
+}
+
+// observe records the delay requested by a failed attempt, if any.
+func (b *retryAfterBackOff) observe(err error) {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		b.hint = apiErr.RetryAfter
+	}
+}
@@ -0,0 +1,147 @@
+package donately
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimiterBurst(t *testing.T) {
+	limiter := newRateLimiter(20, 3)
+
+	start := time.Now()
+	for range 5 {
+		require.NoError(t, limiter.Wait(context.Background()))
+	}
+	elapsed := time.Since(start)
+
+	// The first three requests use the burst, the next two wait 50ms each.
+	assert.GreaterOrEqual(t, elapsed, 90*time.Millisecond)
+	assert.Less(t, elapsed, time.Second)
+}
+
+func TestRateLimiterSharedAcrossGoroutines(t *testing.T) {
+	limiter := newRateLimiter(50, 1)
+
+	start := time.Now()
+	var wg sync.WaitGroup
+	for range 6 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, limiter.Wait(context.Background()))
+		}()
+	}
+	wg.Wait()
+
+	assert.GreaterOrEqual(t, time.Since(start), 90*time.Millisecond)
+}
+
+func TestRateLimiterHonorsContext(t *testing.T) {
+	limiter := newRateLimiter(1, 1)
+	limiter.PauseUntil(time.Now().Add(time.Hour))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	assert.ErrorIs(t, limiter.Wait(ctx), context.DeadlineExceeded)
+}
+
+func TestRetryAfter(t *testing.T) {
+	tests := []struct {
+		name     string
+		header   http.Header
+		expected time.Duration
+	}{
+		{name: "none", header: http.Header{}, expected: 0},
+		{name: "seconds", header: http.Header{"Retry-After": {"3"}}, expected: 3 * time.Second},
+		{name: "invalid", header: http.Header{"Retry-After": {"soon"}}, expected: 0},
+		{name: "reset delta", header: http.Header{"Ratelimit-Reset": {"7"}}, expected: 7 * time.Second},
+		{name: "past reset time", header: http.Header{"X-Ratelimit-Reset": {"1500000000"}}, expected: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, retryAfter(tt.header))
+		})
+	}
+
+	t.Run("http date", func(t *testing.T) {
+		at := time.Now().Add(time.Minute).UTC().Format(http.TimeFormat)
+		delay := retryAfter(http.Header{"Retry-After": {at}})
+		assert.Greater(t, delay, 50*time.Second)
+		assert.LessOrEqual(t, delay, time.Minute)
+	})
+
+	t.Run("reset time", func(t *testing.T) {
+		at := strconv.FormatInt(time.Now().Add(time.Minute).Unix(), 10)
+		delay := retryAfter(http.Header{"X-Ratelimit-Reset": {at}})
+		assert.Greater(t, delay, 50*time.Second)
+	})
+}
+
+func TestRateLimitedRequestHonorsRetryAfter(t *testing.T) {
+	var attempts atomic.Int32
+	server, client := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+
+		json.NewEncoder(w).Encode(APIResponse{Data: mustMarshal(t, []Donation{{ID: "don_1"}})})
+	}, WithRateLimit(100, 10))
+	defer server.Close()
+
+	start := time.Now()
+	donations, err := client.ListDonations(context.Background(), Account{ID: "acc_123"}, 0, 0)
+	require.NoError(t, err)
+
+	assert.Len(t, donations, 1)
+	assert.Equal(t, int32(2), attempts.Load())
+	assert.GreaterOrEqual(t, time.Since(start), 900*time.Millisecond)
+}
+
+func TestRateLimitedErrorAfterBudgetExhausted(t *testing.T) {
+	var attempts atomic.Int32
+	server, client := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		w.Header().Set("Retry-After", "30")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}, WithRateLimit(100, 10), WithMaxElapsedTime(time.Second))
+	defer server.Close()
+
+	_, err := client.FindPerson(context.Background(), "person_123", Account{ID: "acc_123"})
+	require.ErrorIs(t, err, ErrRateLimited)
+
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, 30*time.Second, apiErr.RetryAfter)
+	assert.Equal(t, int32(1), attempts.Load())
+}
+
+func TestRateLimitPausesOnExhaustedQuota(t *testing.T) {
+	server, client := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		w.Header().Set("X-RateLimit-Reset", "3600")
+		json.NewEncoder(w).Encode(APIResponse{Data: mustMarshal(t, Person{ID: "person_123"})})
+	}, WithRateLimit(100, 10))
+	defer server.Close()
+
+	_, err := client.FindPerson(context.Background(), "person_123", Account{ID: "acc_123"})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	_, err = client.FindPerson(ctx, "person_123", Account{ID: "acc_123"})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
@@ -0,0 +1,142 @@
+package donately
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const redacted = "[REDACTED]"
+
+// sensitiveFields lists the JSON, form and query field names whose values are personal or
+// payment information that must not end up in logs or recorded fixtures.
+var sensitiveFields = map[string]bool{
+	"email":            true,
+	"first_name":       true,
+	"last_name":        true,
+	"name":             true,
+	"phone_number":     true,
+	"phone":            true,
+	"street_address":   true,
+	"street_address_2": true,
+	"address_line1":    true,
+	"address_line2":    true,
+	"address_city":     true,
+	"address_state":    true,
+	"address_zip":      true,
+	"address_country":  true,
+	"city":             true,
+	"state":            true,
+	"country":          true,
+	"zip_code":         true,
+	"postal_code":      true,
+	"last4":            true,
+	"dynamic_last4":    true,
+	"cc_last4":         true,
+	"fingerprint":      true,
+	"ip_address":       true,
+	"remote_ip":        true,
+}
+
+// redactHeader returns a copy of h with credentials masked.
+func redactHeader(h http.Header) http.Header {
+	h = h.Clone()
+	if h.Get("Authorization") != "" {
+		h.Set("Authorization", "Bearer "+redacted)
+	}
+
+	return h
+}
+
+// redactEndpoint masks sensitive query parameters of an endpoint.
+func redactEndpoint(endpoint string) string {
+	path, rawQuery, found := strings.Cut(endpoint, "?")
+	if !found {
+		return endpoint
+	}
+
+	return path + "?" + redactValues(rawQuery)
+}
+
+// redactBody masks sensitive fields of a JSON or form encoded body.
+// Bodies that can't be decoded are masked entirely, since their fields can't be told apart.
+func redactBody(body []byte, contentType string) []byte {
+	if len(body) == 0 {
+		return body
+	}
+
+	if strings.HasPrefix(contentType, "application/x-www-form-urlencoded") {
+		return []byte(redactValues(string(body)))
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+
+	var v any
+	if err := decoder.Decode(&v); err != nil {
+		return []byte(redacted)
+	}
+
+	scrubbed, err := json.Marshal(redactValue(v))
+	if err != nil {
+		return []byte(redacted)
+	}
+
+	return scrubbed
+}
+
+// redactError returns the text of err with any APIError it wraps described by its status, code
+// and type only: the API's message may quote submitted values, and its raw response body is redacted.
+func redactError(err error) string {
+	text := err.Error()
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return text
+	}
+
+	scrubbed := fmt.Sprintf("API error: %s - (%s) (HTTP %d)", apiErr.Code, apiErr.Type, apiErr.StatusCode)
+	if apiErr.Code == "" && apiErr.Message == "" {
+		scrubbed = fmt.Sprintf("HTTP error: %d (Raw Response: %s)", apiErr.StatusCode, redactBody(apiErr.Body, "application/json"))
+	}
+
+	return strings.Replace(text, apiErr.Error(), scrubbed, 1)
+}
+
+// redactValues masks sensitive fields of a query or form encoded body. Pairs that can't be
+// parsed are dropped, since their field can't be known.
+func redactValues(rawQuery string) string {
+	values, _ := url.ParseQuery(rawQuery)
+
+	for key := range values {
+		if sensitiveFields[key] {
+			values[key] = []string{redacted}
+		}
+	}
+
+	return values.Encode()
+}
+
+func redactValue(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for key, value := range v {
+			if sensitiveFields[key] && value != nil {
+				v[key] = redacted
+				continue
+			}
+
+			v[key] = redactValue(value)
+		}
+	case []any:
+		for i, value := range v {
+			v[i] = redactValue(value)
+		}
+	}
+
+	return v
+}
@@ -0,0 +1,149 @@
+package donately
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+
+	"github.com/cenkalti/backoff/v5"
+)
+
+const (
+	defaultMaxAttempts    = 5
+	defaultMaxElapsedTime = time.Minute
+)
+
+type retryable interface {
+	CanRetry() bool
+}
+
+type retryableError struct {
+	Err      error
+	canRetry bool
+}
+
+func (e retryableError) Error() string {
+	return e.Err.Error()
+}
+
+func (e retryableError) Unwrap() error {
+	return e.Err
+}
+
+func (e retryableError) CanRetry() bool {
+	return e.canRetry
+}
+
+// WithBackOff returns a ClientOption that sets the backoff strategy used between retry attempts.
+// The factory is invoked once per logical request so that backoff state is never shared
+// between concurrent calls. If not provided, an exponential backoff is used.
+// It only has an effect when retries are enabled with WithRetry.
+func WithBackOff(newBackOff func() backoff.BackOff) ClientOption {
+	return func(opt *clientOption) {
+		opt.newBackOff = newBackOff
+	}
+}
+
+// WithMaxAttempts returns a ClientOption that caps the number of attempts (including the first)
+// made for a single request. If not provided, defaults to 5. Since every request is attempted at
+// least once, 0 is treated as 1.
+// It only has an effect when retries are enabled with WithRetry.
+func WithMaxAttempts(attempts uint) ClientOption {
+	return func(opt *clientOption) {
+		opt.maxAttempts = max(attempts, 1)
+	}
+}
+
+// WithMaxElapsedTime returns a ClientOption that caps the total time spent retrying a single request.
+// If not provided, defaults to one minute.
+// It only has an effect when retries are enabled with WithRetry.
+func WithMaxElapsedTime(d time.Duration) ClientOption {
+	return func(opt *clientOption) {
+		opt.maxElapsedTime = d
+	}
+}
+
+// newBackOff returns the backoff for a single logical request,
+// honoring any delay the API asks for between attempts.
+func (c *donatelyClient) newBackOff() *retryAfterBackOff {
+	if c.opts.newBackOff != nil {
+		return &retryAfterBackOff{backOff: c.opts.newBackOff()}
+	}
+
+	return &retryAfterBackOff{backOff: backoff.NewExponentialBackOff()}
+}
+
+func (c *donatelyClient) retryOptions(b backoff.BackOff) []backoff.RetryOption {
+	return []backoff.RetryOption{
+		backoff.WithBackOff(b),
+		backoff.WithMaxTries(c.opts.maxAttempts),
+		backoff.WithMaxElapsedTime(c.opts.maxElapsedTime),
+	}
+}
+
+// canRetry reports whether a failed attempt should be retried under the client's configuration.
+// Without WithRetry, only throttled requests are retried, and only when rate limiting is enabled.
+func (c *donatelyClient) canRetry(ctx context.Context, method, idempotencyKey string, err error) bool {
+	if !c.opts.doRetry {
+		return ctx.Err() == nil && errors.Is(err, ErrRateLimited)
+	}
+
+	return canRetry(ctx, method, idempotencyKey, err)
+}
+
+// canRetry reports whether a failed attempt may safely be repeated.
+// Failures where the API never processed the request (throttling, "retry later", refused connections)
+// are retried for every method; other transient failures (5xx responses, timeouts, dropped connections)
+// are only retried for idempotent requests, since the request may have already taken effect.
+func canRetry(ctx context.Context, method, idempotencyKey string, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+
+	var re retryable
+	if errors.As(err, &re) {
+		return re.CanRetry()
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		switch {
+		case apiErr.StatusCode == http.StatusTooManyRequests:
+			return true
+		case apiErr.StatusCode >= http.StatusInternalServerError:
+			return isIdempotent(method, idempotencyKey)
+		default:
+			return false
+		}
+	}
+
+	if errors.Is(err, syscall.ECONNREFUSED) {
+		return true
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return isIdempotent(method, idempotencyKey)
+	}
+
+	if errors.Is(err, syscall.ECONNRESET) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return isIdempotent(method, idempotencyKey)
+	}
+
+	return false
+}
+
+// isIdempotent reports whether repeating a request cannot apply its effect twice,
+// either because of its method or because it carries an idempotency key.
+func isIdempotent(method, idempotencyKey string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+
+	return idempotencyKey != ""
+}
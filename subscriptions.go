@@ -1,6 +1,9 @@
 package donately
 
-import "time"
+import (
+	"net/url"
+	"time"
+)
 
 // Subscription represents a recurring donation subscription
 // with payment details, scheduling information, and associated metadata.
@@ -56,3 +59,59 @@ type DonationLite struct {
 	Recurring     bool   `json:"recurring"`
 	Refunded      *bool  `json:"refunded"`
 }
+
+// SubscriptionQuery filters and pages the subscriptions returned by SearchSubscriptions.
+// Zero-valued fields are ignored.
+type SubscriptionQuery struct {
+	// Status restricts results to subscriptions with the given status (e.g. "active" or "cancelled").
+	Status string
+
+	// RecurringFrequency restricts results to subscriptions charged at the given frequency (e.g. "monthly").
+	RecurringFrequency string
+
+	// CampaignID restricts results to subscriptions for the given campaign.
+	CampaignID string
+
+	// PersonID restricts results to subscriptions belonging to the given person.
+	PersonID string
+
+	// NextCharge restricts results to subscriptions whose next charge falls within the range.
+	NextCharge TimeRange
+
+	// Updated restricts results to subscriptions last updated within the range.
+	Updated TimeRange
+
+	// SortBy is the subscription field to sort by, such as "created" or "amount_in_cents".
+	SortBy string
+
+	// Order is the direction results are sorted in. If not provided, the API's default order is used.
+	Order SortOrder
+
+	// Offset is the number of matching subscriptions to skip.
+	Offset int
+
+	// Limit is the maximum number of subscriptions to return.
+	Limit int
+}
+
+func (q SubscriptionQuery) validate() error {
+	if err := q.NextCharge.validate("next charge"); err != nil {
+		return err
+	}
+
+	return q.Updated.validate("updated")
+}
+
+func (q SubscriptionQuery) values() url.Values {
+	params := url.Values{}
+
+	setString(params, "status", q.Status)
+	setString(params, "recurring_frequency", q.RecurringFrequency)
+	setString(params, "campaign_id", q.CampaignID)
+	setString(params, "person_id", q.PersonID)
+	q.NextCharge.set(params, "next_charge_date")
+	q.Updated.set(params, "updated")
+	setSort(params, q.SortBy, q.Order)
+
+	return params
+}
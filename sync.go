@@ -0,0 +1,238 @@
+package donately
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"slices"
+	"sync"
+	"time"
+)
+
+// SyncCursor records how far an incremental sync has progressed, so the next sync only
+// returns records created or changed since. The zero value starts from the beginning.
+// It is safe to persist as JSON.
+type SyncCursor struct {
+	// UpdatedAfter is the latest Updated timestamp seen.
+	UpdatedAfter int64 `json:"updated_after"`
+
+	// IDs holds the records already seen whose Updated timestamp equals UpdatedAfter,
+	// since several records can change within the same second.
+	IDs []string `json:"ids,omitempty"`
+}
+
+func (c SyncCursor) seen(id string, updated int64) bool {
+	return updated < c.UpdatedAfter || (updated == c.UpdatedAfter && slices.Contains(c.IDs, id))
+}
+
+func (c SyncCursor) advance(id string, updated int64) SyncCursor {
+	switch {
+	case updated > c.UpdatedAfter:
+		return SyncCursor{UpdatedAfter: updated, IDs: []string{id}}
+	case updated == c.UpdatedAfter:
+		return SyncCursor{UpdatedAfter: updated, IDs: append(slices.Clip(c.IDs), id)}
+	}
+
+	return c
+}
+
+// updatedRange returns the Updated window to query so records sharing the cursor's timestamp are included.
+func (c SyncCursor) updatedRange() TimeRange {
+	if c.UpdatedAfter == 0 {
+		return TimeRange{}
+	}
+
+	return TimeRange{After: time.Unix(c.UpdatedAfter-1, 0)}
+}
+
+// Sync streams the records of one resource type that were created or changed since a SyncCursor,
+// oldest change first, and tracks the cursor to resume from next time.
+type Sync[T any] struct {
+	// PageSize is the number of records requested per page. Defaults to 100.
+	PageSize int
+
+	// Checkpoints, when set, persists the cursor after each page and when the sync stops, so an
+	// interrupted sync resumes where it stopped. A saved cursor takes precedence over the one the
+	// Sync was created with.
+	Checkpoints CheckpointStore
+
+	key      CheckpointKey
+	fetch    func(ctx context.Context, updated TimeRange, offset, limit int) (Page[T], error)
+	identify func(T) (id string, updated int64)
+
+	mu     sync.Mutex
+	cursor SyncCursor
+}
+
+func newSync[T any](key CheckpointKey, cursor SyncCursor, fetch func(context.Context, TimeRange, int, int) (Page[T], error), identify func(T) (string, int64)) *Sync[T] {
+	cursor.IDs = slices.Clone(cursor.IDs)
+	return &Sync[T]{key: key, fetch: fetch, identify: identify, cursor: cursor}
+}
+
+// Changes returns an iterator over the records changed since the cursor. The cursor advances past
+// each record as it is yielded, so stopping early and calling Cursor resumes after the last record seen.
+//
+// Each page is requested from the current cursor rather than by offset into a fixed window, so
+// records changing while the sync runs, which moves them to the end of the results, don't shift
+// unseen records past the next page. Records at the cursor's timestamp are fetched again at the
+// start of each page and skipped.
+func (s *Sync[T]) Changes(ctx context.Context) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+
+		if err := s.restore(ctx); err != nil {
+			yield(zero, err)
+			return
+		}
+
+		pageSize := s.PageSize
+		if pageSize <= 0 {
+			pageSize = defaultPageSize
+		}
+
+		// offset skips the records at the cursor's timestamp already fetched during this sync, which
+		// sort first. It is only needed when more than a page of records share that timestamp.
+		// Cursor IDs from earlier syncs can't be used instead, since those records may have changed since.
+		offset := 0
+
+		for first := true; ; first = false {
+			if !first {
+				if err := s.checkpoint(ctx); err != nil {
+					yield(zero, err)
+					return
+				}
+			}
+
+			from := s.Cursor()
+
+			page, err := s.fetch(ctx, from.updatedRange(), offset, pageSize)
+			if err != nil {
+				// Best effort: the fetch error is the one worth reporting.
+				s.checkpoint(context.WithoutCancel(ctx))
+				yield(zero, err)
+				return
+			}
+
+			for _, record := range page.Items {
+				id, updated := s.identify(record)
+
+				s.mu.Lock()
+				seen := s.cursor.seen(id, updated)
+				if !seen {
+					s.cursor = s.cursor.advance(id, updated)
+				}
+				s.mu.Unlock()
+
+				if seen {
+					continue
+				}
+
+				if !yield(record, nil) {
+					// The consumer has stopped, so a failure to save can't be reported.
+					s.checkpoint(context.WithoutCancel(ctx))
+					return
+				}
+			}
+
+			if !page.HasMore || len(page.Items) == 0 {
+				break
+			}
+
+			to := s.Cursor()
+
+			switch _, last := s.identify(page.Items[len(page.Items)-1]); {
+			case last != to.UpdatedAfter:
+				offset = 0
+			case to.UpdatedAfter == from.UpdatedAfter:
+				offset += len(page.Items)
+			default:
+				offset = 0
+				for _, record := range page.Items {
+					if _, updated := s.identify(record); updated == to.UpdatedAfter {
+						offset++
+					}
+				}
+			}
+		}
+
+		if err := s.checkpoint(ctx); err != nil {
+			yield(zero, err)
+		}
+	}
+}
+
+// Cursor returns the cursor to pass to the next sync. It covers every record yielded so far.
+func (s *Sync[T]) Cursor() SyncCursor {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return SyncCursor{UpdatedAfter: s.cursor.UpdatedAfter, IDs: slices.Clone(s.cursor.IDs)}
+}
+
+func (s *Sync[T]) restore(ctx context.Context) error {
+	if s.Checkpoints == nil {
+		return nil
+	}
+
+	checkpoint, ok, err := s.Checkpoints.Load(ctx, s.key)
+	if err != nil {
+		return fmt.Errorf("failed to load checkpoint: %w", err)
+	}
+
+	if ok && checkpoint.Cursor != nil {
+		s.mu.Lock()
+		s.cursor = SyncCursor{UpdatedAfter: checkpoint.Cursor.UpdatedAfter, IDs: slices.Clone(checkpoint.Cursor.IDs)}
+		s.mu.Unlock()
+	}
+
+	return nil
+}
+
+func (s *Sync[T]) checkpoint(ctx context.Context) error {
+	if s.Checkpoints == nil {
+		return nil
+	}
+
+	cursor := s.Cursor()
+	if err := s.Checkpoints.Save(ctx, s.key, Checkpoint{Cursor: &cursor}); err != nil {
+		return fmt.Errorf("failed to save checkpoint: %w", err)
+	}
+
+	return nil
+}
+
+func (c *donatelyClient) SyncPeople(account Account, cursor SyncCursor) *Sync[Person] {
+	return newSync(CheckpointKey{AccountID: account.ID, Resource: "sync:people"}, cursor, func(ctx context.Context, updated TimeRange, offset, limit int) (Page[Person], error) {
+		query := PeopleQuery{Updated: updated, SortBy: "updated", Order: SortAscending}
+		return listPage[Person](withOperation(ctx, "SyncPeople"), c, "/people", account, query.values(), offset, limit)
+	}, func(person Person) (string, int64) {
+		return person.ID, person.Updated
+	})
+}
+
+func (c *donatelyClient) SyncDonations(account Account, cursor SyncCursor) *Sync[Donation] {
+	return newSync(CheckpointKey{AccountID: account.ID, Resource: "sync:donations"}, cursor, func(ctx context.Context, updated TimeRange, offset, limit int) (Page[Donation], error) {
+		query := DonationQuery{Updated: updated, SortBy: "updated", Order: SortAscending}
+		return listPage[Donation](withOperation(ctx, "SyncDonations"), c, "/donations", account, query.values(), offset, limit)
+	}, func(donation Donation) (string, int64) {
+		return donation.ID, donation.Updated
+	})
+}
+
+func (c *donatelyClient) SyncSubscriptions(account Account, cursor SyncCursor) *Sync[Subscription] {
+	return newSync(CheckpointKey{AccountID: account.ID, Resource: "sync:subscriptions"}, cursor, func(ctx context.Context, updated TimeRange, offset, limit int) (Page[Subscription], error) {
+		query := SubscriptionQuery{Updated: updated, SortBy: "updated", Order: SortAscending}
+		return listPage[Subscription](withOperation(ctx, "SyncSubscriptions"), c, "/subscriptions", account, query.values(), offset, limit)
+	}, func(subscription Subscription) (string, int64) {
+		return subscription.ID, subscription.Updated
+	})
+}
+
+func (c *donatelyClient) SyncCampaigns(account Account, cursor SyncCursor) *Sync[Campaign] {
+	return newSync(CheckpointKey{AccountID: account.ID, Resource: "sync:campaigns"}, cursor, func(ctx context.Context, updated TimeRange, offset, limit int) (Page[Campaign], error) {
+		query := CampaignQuery{Updated: updated, SortBy: "updated", Order: SortAscending}
+		return listPage[Campaign](withOperation(ctx, "SyncCampaigns"), c, "/campaigns", account, query.values(), offset, limit)
+	}, func(campaign Campaign) (string, int64) {
+		return campaign.ID, campaign.Updated
+	})
+}
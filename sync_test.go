@@ -0,0 +1,229 @@
+package donately
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// donationStore serves donations filtered by updated_after and sorted by Updated, like the API.
+type donationStore struct {
+	mu        sync.Mutex
+	donations []Donation
+	queries   []string
+}
+
+func (s *donationStore) add(id string, updated int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.donations = append(s.donations, Donation{ID: id, Updated: updated})
+}
+
+func (s *donationStore) handler(t *testing.T) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		params := r.URL.Query()
+		s.queries = append(s.queries, params.Get("updated_after"))
+		assert.Equal(t, "updated", params.Get("sort_by"))
+		assert.Equal(t, "asc", params.Get("order"))
+
+		after, _ := strconv.ParseInt(params.Get("updated_after"), 10, 64)
+		offset, _ := strconv.Atoi(params.Get("offset"))
+		limit, _ := strconv.Atoi(params.Get("limit"))
+
+		var matches []Donation
+		for _, donation := range s.donations {
+			if donation.Updated > after {
+				matches = append(matches, donation)
+			}
+		}
+
+		slices.SortStableFunc(matches, func(a, b Donation) int {
+			return cmp.Compare(a.Updated, b.Updated)
+		})
+
+		page := []Donation{}
+		for i := offset; i < len(matches) && i < offset+limit; i++ {
+			page = append(page, matches[i])
+		}
+
+		json.NewEncoder(w).Encode(APIResponse{
+			Data:    mustMarshal(t, page),
+			Summary: &Summary{TotalCount: len(matches), Offset: offset, Limit: limit, HasMore: offset+len(page) < len(matches)},
+		})
+	}
+}
+
+func syncIDs(t *testing.T, syncer *Sync[Donation]) []string {
+	var ids []string
+	for donation, err := range syncer.Changes(context.Background()) {
+		require.NoError(t, err)
+		ids = append(ids, donation.ID)
+	}
+
+	return ids
+}
+
+func TestSyncDonations(t *testing.T) {
+	store := &donationStore{}
+	store.add("don_1", 100)
+	store.add("don_2", 200)
+	store.add("don_3", 200)
+
+	server, client := setupTestServer(t, store.handler(t))
+	defer server.Close()
+
+	account := Account{ID: "acc_123"}
+
+	syncer := client.SyncDonations(account, SyncCursor{})
+	syncer.PageSize = 2
+
+	assert.Equal(t, []string{"don_1", "don_2", "don_3"}, syncIDs(t, syncer))
+
+	cursor := syncer.Cursor()
+	assert.Equal(t, SyncCursor{UpdatedAfter: 200, IDs: []string{"don_2", "don_3"}}, cursor)
+
+	// A change within the same second as the cursor is still picked up, and don_1 moves forward.
+	store.add("don_4", 200)
+	store.mu.Lock()
+	store.donations[0].Updated = 300
+	store.mu.Unlock()
+
+	syncer = client.SyncDonations(account, cursor)
+	assert.Equal(t, []string{"don_4", "don_1"}, syncIDs(t, syncer))
+	assert.Equal(t, SyncCursor{UpdatedAfter: 300, IDs: []string{"don_1"}}, syncer.Cursor())
+
+	assert.Equal(t, []string{"", "199", "199"}, store.queries)
+
+	// Nothing changed since the last sync.
+	syncer = client.SyncDonations(account, syncer.Cursor())
+	assert.Empty(t, syncIDs(t, syncer))
+	assert.Equal(t, SyncCursor{UpdatedAfter: 300, IDs: []string{"don_1"}}, syncer.Cursor())
+}
+
+func TestSyncDonationsChangedDuringSync(t *testing.T) {
+	store := &donationStore{}
+	store.add("don_1", 100)
+	store.add("don_2", 200)
+	store.add("don_3", 300)
+	store.add("don_4", 400)
+
+	server, client := setupTestServer(t, store.handler(t))
+	defer server.Close()
+
+	account := Account{ID: "acc_123"}
+
+	syncer := client.SyncDonations(account, SyncCursor{})
+	syncer.PageSize = 2
+
+	var ids []string
+	for donation, err := range syncer.Changes(context.Background()) {
+		require.NoError(t, err)
+		ids = append(ids, donation.ID)
+
+		// don_1 changes after the first page is fetched, moving to the end of the results.
+		if donation.ID == "don_2" {
+			store.mu.Lock()
+			store.donations[0].Updated = 500
+			store.mu.Unlock()
+		}
+	}
+
+	assert.Equal(t, []string{"don_1", "don_2", "don_3", "don_4", "don_1"}, ids)
+	assert.Equal(t, SyncCursor{UpdatedAfter: 500, IDs: []string{"don_1"}}, syncer.Cursor())
+
+	syncer = client.SyncDonations(account, syncer.Cursor())
+	assert.Empty(t, syncIDs(t, syncer))
+}
+
+func TestSyncDonationsCursorRecordsChanged(t *testing.T) {
+	store := &donationStore{}
+	store.add("don_1", 200)
+	store.add("don_2", 200)
+
+	server, client := setupTestServer(t, store.handler(t))
+	defer server.Close()
+
+	account := Account{ID: "acc_123"}
+	cursor := SyncCursor{UpdatedAfter: 200, IDs: []string{"don_1", "don_2"}}
+
+	// don_1 changed since the last sync, so fewer records than the cursor holds remain at its timestamp.
+	store.add("don_3", 200)
+	store.mu.Lock()
+	store.donations[0].Updated = 300
+	store.mu.Unlock()
+
+	syncer := client.SyncDonations(account, cursor)
+	syncer.PageSize = 2
+	assert.Equal(t, []string{"don_3", "don_1"}, syncIDs(t, syncer))
+}
+
+func TestSyncDonationsSameTimestamp(t *testing.T) {
+	store := &donationStore{}
+	for i := 1; i <= 5; i++ {
+		store.add("don_"+strconv.Itoa(i), 100)
+	}
+	store.add("don_6", 200)
+
+	server, client := setupTestServer(t, store.handler(t))
+	defer server.Close()
+
+	syncer := client.SyncDonations(Account{ID: "acc_123"}, SyncCursor{})
+	syncer.PageSize = 2
+
+	assert.Equal(t, []string{"don_1", "don_2", "don_3", "don_4", "don_5", "don_6"}, syncIDs(t, syncer))
+	assert.Equal(t, SyncCursor{UpdatedAfter: 200, IDs: []string{"don_6"}}, syncer.Cursor())
+}
+
+func TestSyncDonationsStoppedEarly(t *testing.T) {
+	store := &donationStore{}
+	store.add("don_1", 100)
+	store.add("don_2", 200)
+	store.add("don_3", 300)
+
+	server, client := setupTestServer(t, store.handler(t))
+	defer server.Close()
+
+	syncer := client.SyncDonations(Account{ID: "acc_123"}, SyncCursor{})
+	for donation, err := range syncer.Changes(context.Background()) {
+		require.NoError(t, err)
+		if donation.ID == "don_2" {
+			break
+		}
+	}
+
+	assert.Equal(t, SyncCursor{UpdatedAfter: 200, IDs: []string{"don_2"}}, syncer.Cursor())
+
+	syncer = client.SyncDonations(Account{ID: "acc_123"}, syncer.Cursor())
+	assert.Equal(t, []string{"don_3"}, syncIDs(t, syncer))
+}
+
+func TestSyncDonationsYieldsErrors(t *testing.T) {
+	server, client := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	})
+	defer server.Close()
+
+	cursor := SyncCursor{UpdatedAfter: 100, IDs: []string{"don_1"}}
+	syncer := client.SyncDonations(Account{ID: "acc_123"}, cursor)
+
+	var errs []error
+	for _, err := range syncer.Changes(context.Background()) {
+		errs = append(errs, err)
+	}
+
+	require.Len(t, errs, 1)
+	assert.ErrorIs(t, errs[0], ErrUnauthorized)
+	assert.Equal(t, cursor, syncer.Cursor())
+}
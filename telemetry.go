@@ -0,0 +1,201 @@
+package donately
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/willmadison/donately"
+
+type operationContextKey struct{}
+
+// withOperation tags ctx with the name of the Client method being executed.
+func withOperation(ctx context.Context, operation string) context.Context {
+	return context.WithValue(ctx, operationContextKey{}, operation)
+}
+
+func operationFromContext(ctx context.Context) string {
+	if operation, ok := ctx.Value(operationContextKey{}).(string); ok {
+		return operation
+	}
+
+	return "Request"
+}
+
+// WithTracerProvider returns a ClientOption that records a span for every Client method call
+// using the given OpenTelemetry tracer provider. Spans are named after the method (e.g. "donately.SaveDonation")
+// and carry the endpoint, account ID, HTTP status, Donately request ID and retry count.
+// If not provided, no spans are recorded.
+func WithTracerProvider(provider trace.TracerProvider) ClientOption {
+	return func(opt *clientOption) {
+		opt.tracerProvider = provider
+	}
+}
+
+// WithMeterProvider returns a ClientOption that records the latency and error count of every
+// Client method call using the given OpenTelemetry meter provider.
+// If not provided, no metrics are recorded.
+func WithMeterProvider(provider metric.MeterProvider) ClientOption {
+	return func(opt *clientOption) {
+		opt.meterProvider = provider
+	}
+}
+
+type telemetry struct {
+	tracer   trace.Tracer
+	duration metric.Float64Histogram
+	errors   metric.Int64Counter
+}
+
+func newTelemetry(tracerProvider trace.TracerProvider, meterProvider metric.MeterProvider) (*telemetry, error) {
+	if tracerProvider == nil && meterProvider == nil {
+		return nil, nil
+	}
+
+	t := &telemetry{}
+
+	if tracerProvider != nil {
+		t.tracer = tracerProvider.Tracer(instrumentationName)
+	}
+
+	if meterProvider != nil {
+		meter := meterProvider.Meter(instrumentationName)
+
+		var err error
+		t.duration, err = meter.Float64Histogram(
+			"donately.client.duration",
+			metric.WithDescription("Duration of Donately API calls, including retries."),
+			metric.WithUnit("s"),
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		t.errors, err = meter.Int64Counter(
+			"donately.client.errors",
+			metric.WithDescription("Number of failed Donately API calls."),
+			metric.WithUnit("{error}"),
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return t, nil
+}
+
+// callInfo collects what is known about a logical call once its attempts are done.
+type callInfo struct {
+	attempts   int
+	statusCode int
+	requestID  string
+}
+
+// start begins instrumenting a logical call and returns the function that completes it.
+func (t *telemetry) start(ctx context.Context, req Request) (context.Context, func(callInfo, error)) {
+	operation := operationFromContext(ctx)
+	path, _, _ := strings.Cut(req.Endpoint, "?")
+
+	attrs := []attribute.KeyValue{
+		attribute.String("donately.operation", operation),
+		attribute.String("http.request.method", req.Method),
+	}
+
+	var span trace.Span
+	if t.tracer != nil {
+		spanAttrs := slices.Concat(attrs, []attribute.KeyValue{attribute.String("donately.endpoint", path)})
+		if accountID := requestAccountID(req); accountID != "" {
+			spanAttrs = append(spanAttrs, attribute.String("donately.account_id", accountID))
+		}
+
+		ctx, span = t.tracer.Start(ctx, "donately."+operation,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(spanAttrs...),
+		)
+	}
+
+	start := time.Now()
+
+	return ctx, func(info callInfo, err error) {
+		if info.statusCode != 0 {
+			attrs = append(attrs, attribute.Int("http.response.status_code", info.statusCode))
+		}
+
+		if err != nil {
+			attrs = append(attrs, attribute.String("error.type", errorType(err)))
+		}
+
+		if t.duration != nil {
+			t.duration.Record(ctx, time.Since(start).Seconds(), metric.WithAttributes(attrs...))
+		}
+
+		if t.errors != nil && err != nil {
+			t.errors.Add(ctx, 1, metric.WithAttributes(attrs...))
+		}
+
+		if span == nil {
+			return
+		}
+
+		span.SetAttributes(attribute.Int("donately.retry_count", max(info.attempts-1, 0)))
+
+		if info.statusCode != 0 {
+			span.SetAttributes(attribute.Int("http.response.status_code", info.statusCode))
+		}
+
+		if info.requestID != "" {
+			span.SetAttributes(attribute.String("donately.request_id", info.requestID))
+		}
+
+		if err != nil {
+			// Spans are exported outside the client, so API error bodies are redacted from them.
+			span.RecordError(errors.New(redactError(err)))
+			span.SetStatus(codes.Error, errorType(err))
+		}
+
+		span.End()
+	}
+}
+
+// requestAccountID extracts the account a request is scoped to from its query or form body.
+func requestAccountID(req Request) string {
+	if _, rawQuery, found := strings.Cut(req.Endpoint, "?"); found {
+		if values, err := url.ParseQuery(rawQuery); err == nil && values.Get("account_id") != "" {
+			return values.Get("account_id")
+		}
+	}
+
+	if req.ContentType == "application/x-www-form-urlencoded" {
+		if values, err := url.ParseQuery(string(req.Body)); err == nil {
+			return values.Get("account_id")
+		}
+	}
+
+	return ""
+}
+
+func errorType(err error) string {
+	var apiErr *APIError
+
+	switch {
+	case errors.Is(err, ErrCircuitOpen):
+		return "circuit_open"
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return "canceled"
+	case errors.As(err, &apiErr) && apiErr.Code != "":
+		return apiErr.Code
+	case errors.As(err, &apiErr):
+		return "http_error"
+	}
+
+	return "transport_error"
+}
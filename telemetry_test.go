@@ -0,0 +1,174 @@
+package donately
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestWithTracerProvider(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+	attempts := 0
+	server, client := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		json.NewEncoder(w).Encode(APIResponse{
+			Data:      mustMarshal(t, Donation{ID: "don_new"}),
+			RequestID: "req_123",
+		})
+	}, WithTracerProvider(provider), WithRetry(), WithBackOff(fastBackOff))
+	defer server.Close()
+
+	_, err := client.SaveDonation(context.Background(), Donation{Account: Account{ID: "acc_123"}, AmountInCents: 1000})
+	require.NoError(t, err)
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 1)
+
+	span := spans[0]
+	assert.Equal(t, "donately.SaveDonation", span.Name)
+	assert.Equal(t, trace.SpanKindClient, span.SpanKind)
+	assert.Equal(t, codes.Unset, span.Status.Code)
+
+	attrs := attributeMap(span.Attributes)
+	assert.Equal(t, "SaveDonation", attrs["donately.operation"].AsString())
+	assert.Equal(t, "POST", attrs["http.request.method"].AsString())
+	assert.Equal(t, "/donations", attrs["donately.endpoint"].AsString())
+	assert.Equal(t, "acc_123", attrs["donately.account_id"].AsString())
+	assert.Equal(t, int64(200), attrs["http.response.status_code"].AsInt64())
+	assert.Equal(t, "req_123", attrs["donately.request_id"].AsString())
+	assert.Equal(t, int64(1), attrs["donately.retry_count"].AsInt64())
+}
+
+func TestWithTracerProviderRecordsErrors(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+	server, client := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(APIResponse{Type: "invalid_request_error", Message: "not found", Code: "not_found", RequestID: "req_404"})
+	}, WithTracerProvider(provider))
+	defer server.Close()
+
+	_, err := client.FindPerson(context.Background(), "person_123", Account{ID: "acc_123"})
+	require.Error(t, err)
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 1)
+
+	span := spans[0]
+	assert.Equal(t, "donately.FindPerson", span.Name)
+	assert.Equal(t, codes.Error, span.Status.Code)
+	require.Len(t, span.Events, 1)
+	assert.Equal(t, "exception", span.Events[0].Name)
+
+	assert.Equal(t, "not_found", span.Status.Description)
+
+	attrs := attributeMap(span.Attributes)
+	assert.Equal(t, int64(404), attrs["http.response.status_code"].AsInt64())
+	assert.Equal(t, "req_404", attrs["donately.request_id"].AsString())
+	assert.Equal(t, int64(0), attrs["donately.retry_count"].AsInt64())
+}
+
+func TestWithTracerProviderRedactsErrors(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+	server, client := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(`{"data":{"email":"jane@example.com","last4":"4242"}}`))
+	}, WithTracerProvider(provider))
+	defer server.Close()
+
+	_, err := client.FindPerson(context.Background(), "person_123", Account{ID: "acc_123"})
+	require.Error(t, err)
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 1)
+
+	span := spans[0]
+	assert.Equal(t, codes.Error, span.Status.Code)
+	assert.Equal(t, "http_error", span.Status.Description)
+	require.Len(t, span.Events, 1)
+
+	for _, attr := range span.Events[0].Attributes {
+		assert.NotContains(t, attr.Value.Emit(), "jane@example.com")
+		assert.NotContains(t, attr.Value.Emit(), "4242")
+	}
+}
+
+func TestWithMeterProvider(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	fail := false
+	server, client := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if fail {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		json.NewEncoder(w).Encode(APIResponse{Data: mustMarshal(t, Account{ID: "acc_123"})})
+	}, WithMeterProvider(provider))
+	defer server.Close()
+
+	_, err := client.FindAccount(context.Background(), "acc_123")
+	require.NoError(t, err)
+
+	fail = true
+	_, err = client.FindAccount(context.Background(), "acc_123")
+	require.Error(t, err)
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	require.Len(t, rm.ScopeMetrics, 1)
+
+	metrics := map[string]metricdata.Metrics{}
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		metrics[m.Name] = m
+	}
+
+	duration, ok := metrics["donately.client.duration"].Data.(metricdata.Histogram[float64])
+	require.True(t, ok)
+	var count uint64
+	for _, dp := range duration.DataPoints {
+		count += dp.Count
+		operation, _ := dp.Attributes.Value("donately.operation")
+		assert.Equal(t, "FindAccount", operation.AsString())
+	}
+	assert.Equal(t, uint64(2), count)
+
+	errorCount, ok := metrics["donately.client.errors"].Data.(metricdata.Sum[int64])
+	require.True(t, ok)
+	require.Len(t, errorCount.DataPoints, 1)
+	assert.Equal(t, int64(1), errorCount.DataPoints[0].Value)
+
+	status, _ := errorCount.DataPoints[0].Attributes.Value("http.response.status_code")
+	assert.Equal(t, int64(500), status.AsInt64())
+}
+
+func attributeMap(attrs []attribute.KeyValue) map[attribute.Key]attribute.Value {
+	m := map[attribute.Key]attribute.Value{}
+	for _, attr := range attrs {
+		m[attr.Key] = attr.Value
+	}
+
+	return m
+}
@@ -0,0 +1,221 @@
+package donately
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// WebhookSignatureHeader is the header carrying the signature of a webhook request.
+	WebhookSignatureHeader = "Donately-Signature"
+
+	defaultWebhookTolerance = 5 * time.Minute
+	maxWebhookBodySize      = 1 << 20
+)
+
+// ErrInvalidSignature is returned when a webhook request isn't signed with the shared secret,
+// or was signed too long ago.
+var ErrInvalidSignature = errors.New("donately: invalid webhook signature")
+
+// SignWebhookPayload returns the value of the WebhookSignatureHeader for payload sent at timestamp:
+// "t=<unix timestamp>,v1=<hex HMAC-SHA256 of "<unix timestamp>.<payload>" keyed with secret>".
+// It is useful for testing webhook handlers.
+func SignWebhookPayload(secret string, timestamp time.Time, payload []byte) string {
+	t := strconv.FormatInt(timestamp.Unix(), 10)
+	return "t=" + t + ",v1=" + hex.EncodeToString(webhookMAC(secret, t, payload))
+}
+
+// VerifyWebhookSignature checks that header, the value of the WebhookSignatureHeader, is a valid
+// signature of payload with secret made within tolerance of now. The header may carry several v1
+// signatures, as happens while the secret is being rotated; any one of them matching is enough.
+// The returned error matches ErrInvalidSignature.
+func VerifyWebhookSignature(payload []byte, header, secret string, tolerance time.Duration, now time.Time) error {
+	var timestamp string
+	var signatures [][]byte
+
+	for _, part := range strings.Split(header, ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(part), "=")
+		switch key {
+		case "t":
+			timestamp = value
+		case "v1":
+			if signature, err := hex.DecodeString(value); err == nil {
+				signatures = append(signatures, signature)
+			}
+		}
+	}
+
+	if timestamp == "" || len(signatures) == 0 {
+		return fmt.Errorf("%w: malformed %s header", ErrInvalidSignature, WebhookSignatureHeader)
+	}
+
+	signedAt, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return fmt.Errorf("%w: malformed timestamp", ErrInvalidSignature)
+	}
+
+	if age := now.Sub(time.Unix(signedAt, 0)); tolerance > 0 && (age > tolerance || age < -tolerance) {
+		return fmt.Errorf("%w: timestamp is outside the tolerance of %s", ErrInvalidSignature, tolerance)
+	}
+
+	expected := webhookMAC(secret, timestamp, payload)
+	for _, signature := range signatures {
+		if hmac.Equal(signature, expected) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: no signature matches the payload", ErrInvalidSignature)
+}
+
+func webhookMAC(secret, timestamp string, payload []byte) []byte {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
+
+// WebhookOption configures a WebhookHandler.
+type WebhookOption func(*WebhookHandler)
+
+// WithDispatcher returns a WebhookOption that sets the Dispatcher events are passed to, so handlers
+// can be shared with other sources of events. If not provided, the WebhookHandler has its own.
+func WithDispatcher(dispatcher *Dispatcher) WebhookOption {
+	return func(h *WebhookHandler) {
+		h.dispatcher = dispatcher
+	}
+}
+
+// WithEventStore returns a WebhookOption that sets the EventStore events are recorded in, so that
+// events delivered more than once are only dispatched once. If not provided, every delivery is dispatched.
+func WithEventStore(store EventStore) WebhookOption {
+	return func(h *WebhookHandler) {
+		h.events = store
+	}
+}
+
+// WithWebhookTolerance returns a WebhookOption that sets how far the signature timestamp of a
+// webhook request may be from the current time, to protect against replayed requests.
+// A tolerance of 0 disables the check. If not provided, defaults to 5 minutes.
+func WithWebhookTolerance(tolerance time.Duration) WebhookOption {
+	return func(h *WebhookHandler) {
+		h.tolerance = tolerance
+	}
+}
+
+// WebhookHandler is an http.Handler that receives Donately webhook requests, verifies their
+// signature, and dispatches the decoded events to the handlers registered for their type.
+// Requests are answered with:
+//   - 200 once every handler succeeds, when no handler is registered for the event type, or when
+//     the event was already handled;
+//   - 400 when the signature is invalid or the body isn't an event, or, with an EventStore, when
+//     the event has no ID;
+//   - 500 when a handler fails, so the event is delivered again.
+//
+// With an EventStore, an event whose handlers fail is released, so that its redelivery is dispatched.
+// It stays in the store, so it can also be replayed once the handler is fixed.
+type WebhookHandler struct {
+	secret     string
+	tolerance  time.Duration
+	now        func() time.Time
+	dispatcher *Dispatcher
+	events     EventStore
+}
+
+// NewWebhookHandler returns a WebhookHandler that verifies requests with the shared secret
+// configured for the webhook in Donately. An error is returned when secret is empty, since anyone
+// could then sign requests.
+func NewWebhookHandler(secret string, options ...WebhookOption) (*WebhookHandler, error) {
+	if secret == "" {
+		return nil, errors.New("missing webhook secret")
+	}
+
+	h := &WebhookHandler{
+		secret:    secret,
+		tolerance: defaultWebhookTolerance,
+		now:       time.Now,
+	}
+
+	for _, option := range options {
+		option(h)
+	}
+
+	if h.dispatcher == nil {
+		h.dispatcher = NewDispatcher()
+	}
+
+	return h, nil
+}
+
+// On registers handlers for events of the given type with the handler's Dispatcher.
+func (h *WebhookHandler) On(eventType EventType, handlers ...EventHandler) {
+	h.dispatcher.On(eventType, handlers...)
+}
+
+func (h *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	payload, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxWebhookBodySize))
+	if err != nil {
+		http.Error(w, "failed to read body", http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	if err := VerifyWebhookSignature(payload, r.Header.Get(WebhookSignatureHeader), h.secret, h.tolerance, h.now()); err != nil {
+		http.Error(w, "invalid signature", http.StatusBadRequest)
+		return
+	}
+
+	var event Event
+	if err := json.Unmarshal(payload, &event); err != nil || event.Type == "" {
+		http.Error(w, "malformed event", http.StatusBadRequest)
+		return
+	}
+
+	if h.events != nil {
+		// Events without an ID can't be told apart, so they would all count as duplicates of the first.
+		if event.ID == "" {
+			http.Error(w, "malformed event", http.StatusBadRequest)
+			return
+		}
+
+		claimed, err := h.events.Claim(r.Context(), event)
+		if err != nil {
+			http.Error(w, "failed to record event", http.StatusInternalServerError)
+			return
+		}
+
+		if !claimed {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+	}
+
+	if err := h.dispatcher.Dispatch(r.Context(), event); err != nil {
+		if h.events != nil {
+			// Best effort: if the release fails, the redelivery is treated as a duplicate, but the
+			// event is still in the store and can be replayed.
+			_ = h.events.Release(context.WithoutCancel(r.Context()), event.ID)
+		}
+
+		http.Error(w, "failed to handle event", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
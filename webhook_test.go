@@ -0,0 +1,255 @@
+package donately
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testWebhookSecret = "whsec_test"
+
+func webhookRequest(t *testing.T, payload string, signature string) *http.Request {
+	t.Helper()
+
+	req := httptest.NewRequest(http.MethodPost, "/webhooks/donately", strings.NewReader(payload))
+	if signature != "" {
+		req.Header.Set(WebhookSignatureHeader, signature)
+	}
+
+	return req
+}
+
+func newTestWebhookHandler(t *testing.T, options ...WebhookOption) *WebhookHandler {
+	t.Helper()
+
+	handler, err := NewWebhookHandler(testWebhookSecret, options...)
+	require.NoError(t, err)
+
+	return handler
+}
+
+func serveWebhook(handler http.Handler, req *http.Request) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, req)
+	return recorder
+}
+
+func TestWebhookHandler(t *testing.T) {
+	handler := newTestWebhookHandler(t)
+
+	var received []Donation
+	handler.On("donation.created", func(ctx context.Context, event Event) error {
+		assert.Equal(t, "evt_1", event.ID)
+		assert.Equal(t, "acc_123", event.AccountID)
+
+		donation, err := event.Donation()
+		require.NoError(t, err)
+
+		received = append(received, donation)
+		return nil
+	})
+
+	payload := `{"id":"evt_1","type":"donation.created","created":1704067200,"account_id":"acc_123","data":{"id":"don_1","amount_in_cents":2500,"person":{"email":"donor@example.com"}}}`
+
+	resp := serveWebhook(handler, webhookRequest(t, payload, SignWebhookPayload(testWebhookSecret, time.Now(), []byte(payload))))
+	assert.Equal(t, http.StatusOK, resp.Code)
+
+	require.Len(t, received, 1)
+	assert.Equal(t, "don_1", received[0].ID)
+	assert.Equal(t, int64(2500), received[0].AmountInCents)
+	assert.Equal(t, "donor@example.com", received[0].Person.Email)
+
+	// Events nobody listens to are acknowledged.
+	payload = `{"id":"evt_2","type":"campaign.updated","data":{"id":"camp_1"}}`
+	resp = serveWebhook(handler, webhookRequest(t, payload, SignWebhookPayload(testWebhookSecret, time.Now(), []byte(payload))))
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Len(t, received, 1)
+}
+
+func TestWebhookHandlerRejectsBadRequests(t *testing.T) {
+	handler := newTestWebhookHandler(t, WithWebhookTolerance(time.Minute))
+	handler.On("donation.created", func(ctx context.Context, event Event) error {
+		t.Error("handler should not be called")
+		return nil
+	})
+
+	payload := `{"id":"evt_1","type":"donation.created","data":{}}`
+	now := time.Now()
+
+	tests := map[string]struct {
+		req  *http.Request
+		code int
+	}{
+		"unsigned":     {webhookRequest(t, payload, ""), http.StatusBadRequest},
+		"wrong secret": {webhookRequest(t, payload, SignWebhookPayload("whsec_other", now, []byte(payload))), http.StatusBadRequest},
+		"tampered":     {webhookRequest(t, strings.Replace(payload, "evt_1", "evt_2", 1), SignWebhookPayload(testWebhookSecret, now, []byte(payload))), http.StatusBadRequest},
+		"expired":      {webhookRequest(t, payload, SignWebhookPayload(testWebhookSecret, now.Add(-2*time.Minute), []byte(payload))), http.StatusBadRequest},
+		"future":       {webhookRequest(t, payload, SignWebhookPayload(testWebhookSecret, now.Add(2*time.Minute), []byte(payload))), http.StatusBadRequest},
+		"not an event": {webhookRequest(t, `[]`, SignWebhookPayload(testWebhookSecret, now, []byte(`[]`))), http.StatusBadRequest},
+		"wrong method": {httptest.NewRequest(http.MethodGet, "/webhooks/donately", nil), http.StatusMethodNotAllowed},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tt.code, serveWebhook(handler, tt.req).Code)
+		})
+	}
+	// Why verification failed isn't disclosed to the caller.
+	resp := serveWebhook(handler, webhookRequest(t, payload, SignWebhookPayload(testWebhookSecret, now.Add(-2*time.Minute), []byte(payload))))
+	assert.Equal(t, "invalid signature\n", resp.Body.String())
+}
+
+func TestNewWebhookHandlerRequiresSecret(t *testing.T) {
+	_, err := NewWebhookHandler("")
+	assert.ErrorContains(t, err, "missing webhook secret")
+}
+
+func TestWebhookHandlerHandlerErrors(t *testing.T) {
+	handler := newTestWebhookHandler(t)
+	handler.On("subscription.cancelled", func(ctx context.Context, event Event) error {
+		return errors.New("database unavailable")
+	})
+
+	payload := `{"id":"evt_1","type":"subscription.cancelled","data":{"id":"sub_1"}}`
+	resp := serveWebhook(handler, webhookRequest(t, payload, SignWebhookPayload(testWebhookSecret, time.Now(), []byte(payload))))
+
+	assert.Equal(t, http.StatusInternalServerError, resp.Code)
+}
+
+func TestWebhookHandlerWithDispatcher(t *testing.T) {
+	dispatcher := NewDispatcher()
+
+	var types []EventType
+	dispatcher.OnAny(func(ctx context.Context, event Event) error {
+		types = append(types, event.Type)
+		return nil
+	})
+
+	handler := newTestWebhookHandler(t, WithDispatcher(dispatcher))
+	handler.On(EventDonationRefunded, func(ctx context.Context, event Event) error {
+		panic("unexpected refund")
+	})
+
+	payload := `{"id":"evt_1","type":"donation.refunded","data":{"id":"don_1"}}`
+	resp := serveWebhook(handler, webhookRequest(t, payload, SignWebhookPayload(testWebhookSecret, time.Now(), []byte(payload))))
+
+	assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	assert.Equal(t, []EventType{EventDonationRefunded}, types)
+}
+
+func TestVerifyWebhookSignature(t *testing.T) {
+	payload := []byte(`{"id":"evt_1"}`)
+	now := time.Unix(1704067200, 0)
+
+	signature := SignWebhookPayload(testWebhookSecret, now, payload)
+	assert.Regexp(t, `^t=1704067200,v1=[0-9a-f]{64}$`, signature)
+	assert.NoError(t, VerifyWebhookSignature(payload, signature, testWebhookSecret, time.Minute, now.Add(30*time.Second)))
+
+	// While the secret is rotated, the header carries a signature for each secret.
+	rotated := SignWebhookPayload("whsec_old", now, payload) + "," + strings.Split(signature, ",")[1]
+	assert.NoError(t, VerifyWebhookSignature(payload, rotated, testWebhookSecret, time.Minute, now))
+
+	// A tolerance of 0 disables the timestamp check.
+	assert.NoError(t, VerifyWebhookSignature(payload, signature, testWebhookSecret, 0, now.Add(24*time.Hour)))
+
+	for _, header := range []string{"", "t=1704067200", "v1=abcd", "t=soon,v1=abcd", signature + "0"} {
+		assert.ErrorIs(t, VerifyWebhookSignature(payload, header, testWebhookSecret, time.Minute, now), ErrInvalidSignature, header)
+	}
+}
+
+func TestWebhookHandlerDeduplicatesEvents(t *testing.T) {
+	store := NewMemoryEventStore(100, time.Hour)
+	handler := newTestWebhookHandler(t, WithEventStore(store))
+
+	calls := 0
+	handler.On(EventDonationCreated, func(ctx context.Context, event Event) error {
+		calls++
+		if calls == 1 {
+			return errors.New("database unavailable")
+		}
+
+		return nil
+	})
+
+	payload := `{"id":"evt_1","type":"donation.created","data":{"id":"don_1"}}`
+	deliver := func() int {
+		return serveWebhook(handler, webhookRequest(t, payload, SignWebhookPayload(testWebhookSecret, time.Now(), []byte(payload)))).Code
+	}
+
+	assert.Equal(t, http.StatusInternalServerError, deliver())
+	assert.Equal(t, http.StatusOK, deliver(), "a failed event is handled again when redelivered")
+	assert.Equal(t, http.StatusOK, deliver())
+	assert.Equal(t, 2, calls, "a handled event is not handled again")
+
+	events, err := store.Events(context.Background(), EventFilter{})
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, "evt_1", events[0].ID)
+}
+
+func TestWebhookHandlerKeepsFailedEventsForReplay(t *testing.T) {
+	store := NewMemoryEventStore(100, time.Hour)
+	handler := newTestWebhookHandler(t, WithEventStore(store))
+
+	fixed := false
+	handler.On(EventDonationCreated, func(ctx context.Context, event Event) error {
+		if !fixed {
+			return errors.New("bug")
+		}
+
+		return nil
+	})
+
+	payload := `{"id":"evt_1","type":"donation.created","data":{"id":"don_1"}}`
+	resp := serveWebhook(handler, webhookRequest(t, payload, SignWebhookPayload(testWebhookSecret, time.Now(), []byte(payload))))
+	assert.Equal(t, http.StatusInternalServerError, resp.Code)
+
+	fixed = true
+
+	// The sender gave up retrying, but the event can be replayed once the bug is fixed.
+	dispatcher := NewDispatcher()
+	dispatcher.On(EventDonationCreated, func(ctx context.Context, event Event) error {
+		assert.Equal(t, "evt_1", event.ID)
+		return nil
+	})
+
+	count, err := dispatcher.Replay(context.Background(), store, EventFilter{})
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+}
+
+func TestWebhookHandlerRejectsEventsWithoutID(t *testing.T) {
+	handler := newTestWebhookHandler(t, WithEventStore(NewMemoryEventStore(100, time.Hour)))
+
+	calls := 0
+	handler.On(EventDonationCreated, func(ctx context.Context, event Event) error {
+		calls++
+		return nil
+	})
+
+	payload := `{"type":"donation.created","data":{"id":"don_1"}}`
+	for range 2 {
+		resp := serveWebhook(handler, webhookRequest(t, payload, SignWebhookPayload(testWebhookSecret, time.Now(), []byte(payload))))
+		assert.Equal(t, http.StatusBadRequest, resp.Code)
+	}
+
+	assert.Zero(t, calls)
+
+	// Without an EventStore there is nothing to deduplicate, so the event is dispatched.
+	handler = newTestWebhookHandler(t)
+	handler.On(EventDonationCreated, func(ctx context.Context, event Event) error {
+		calls++
+		return nil
+	})
+
+	resp := serveWebhook(handler, webhookRequest(t, payload, SignWebhookPayload(testWebhookSecret, time.Now(), []byte(payload))))
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, 1, calls)
+}